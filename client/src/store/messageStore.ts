@@ -75,7 +75,7 @@ export const useMessageStore = create<MessageState>((set, get) => ({
     }
   },
   
-  connectWebSocket: (roomId: number) => {
+  connectWebSocket: async (roomId: number) => {
     const { socket, connectionStatus } = get();
     
     // 이미 연결되어 있거나 연결 중이면 리턴
@@ -100,14 +100,21 @@ export const useMessageStore = create<MessageState>((set, get) => ({
         return;
       }
       
+      // 티켓을 받는 동안 중복 연결 방지
+      set({ connectionStatus: 'connecting' });
+
+      // 액세스 토큰이 URL(프록시, 접근 로그)에 남지 않도록 일회용 연결 티켓 발급
+      const ticketResponse = await api.post('/protected/ws-ticket');
+      const ticket: string = ticketResponse.data.ticket;
+
       // WebSocket URL 확인 - 백엔드 주소와 일치해야 함
       // const wsProtocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
       const wsProtocol = 'wss:'
       // 개발 환경에서는 다른 포트를 사용할 수 있으므로 조건부 URL 생성
       const baseUrl = import.meta.env.DEV ? 'localhost:8080' : window.location.host;
-      const wsUrl = `${wsProtocol}//${baseUrl}/api/ws?token=${token}`;
+      const wsUrl = `${wsProtocol}//${baseUrl}/api/ws?ticket=${encodeURIComponent(ticket)}`;
       
-      console.log('Connecting to WebSocket URL:', wsUrl);
+      console.log('Connecting to WebSocket');
       const newSocket = new WebSocket(wsUrl);
       
       // 연결 상태 설정
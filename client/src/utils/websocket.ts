@@ -1,9 +1,9 @@
 import { WEBSOCKET_RECONNECT_MAX_ATTEMPTS } from '../constants';
 
 /**
- * 웹소켓 URL을 생성합니다.
+ * 웹소켓 URL을 생성합니다. 액세스 토큰 대신 /protected/ws-ticket에서 받은 일회용 티켓을 사용합니다.
  */
-export function createWebSocketUrl(token: string): string {
+export function createWebSocketUrl(ticket: string): string {
   const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
   
   // API URL에서 호스트 추출 또는 현재 호스트 사용
@@ -19,7 +19,7 @@ export function createWebSocketUrl(token: string): string {
   }
   
   // 최종 웹소켓 URL
-  return `${protocol}//${host}/ws?token=${token}`;
+  return `${protocol}//${host}/ws?ticket=${encodeURIComponent(ticket)}`;
 }
 
 /**
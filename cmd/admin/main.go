@@ -0,0 +1,219 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	errs "errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/handler"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+	"mult-working/pkg/database"
+	"mult-working/pkg/kafka"
+)
+
+// 관리자용 명령줄 도구
+//
+//	go run ./cmd/admin create-admin -username root -email root@example.com
+//	go run ./cmd/admin revoke-sessions -user alice
+//	go run ./cmd/admin generate-key -mode local -id 2026-11
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "generate-key":
+		// 설정 없이도 실행할 수 있어야 함
+		err = generateKey(os.Args[2:])
+	case "create-admin":
+		err = withConfig(os.Args[2:], createAdmin)
+	case "revoke-sessions":
+		err = withConfig(os.Args[2:], revokeSessions)
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: admin <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  generate-key [-mode local|public] [-id <key id>]   generate a token key and print its config entry")
+	fmt.Fprintln(os.Stderr, "  create-admin -username <name> [-email <email>] [-password <password>]   create an admin, or promote an existing user")
+	fmt.Fprintln(os.Stderr, "  revoke-sessions -user <id|username>   revoke all tokens and sessions of a user")
+}
+
+// withConfig는 설정을 불러온 뒤 명령을 실행합니다
+func withConfig(args []string, run func(cfg *config.Config, args []string) error) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	return run(cfg, args)
+}
+
+// generateKey는 새 토큰 키를 생성하고 auth.keys에 붙여 넣을 설정을 출력합니다
+func generateKey(args []string) error {
+	fs := flag.NewFlagSet("generate-key", flag.ExitOnError)
+	mode := fs.String("mode", service.TokenModeLocal, "token mode (local or public)")
+	id := fs.String("id", time.Now().UTC().Format("2006-01-02"), "key ID carried in the token footer")
+	fs.Parse(args)
+
+	key, err := service.GenerateKey(*mode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("    - id: %q\n", *id)
+	if key.Secret != "" {
+		fmt.Printf("      secret: %q\n", key.Secret)
+	}
+	if key.PrivateKey != "" {
+		fmt.Printf("      private_key: %q\n", key.PrivateKey)
+		fmt.Printf("      # public_key: %q  # 개인 키를 제거한 뒤 검증 전용으로 남길 때 사용\n", key.PublicKey)
+	}
+	fmt.Fprintf(os.Stderr, "add the entry to auth.keys and set auth.active_key_id: %q to start issuing tokens with it\n", *id)
+	return nil
+}
+
+// createAdmin은 첫 관리자 계정을 만듭니다. 같은 이름의 사용자가 있으면 관리자로 승격합니다.
+// 비밀번호를 지정하지 않으면 임의로 생성해 한 번만 출력합니다
+func createAdmin(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	username := fs.String("username", "", "username of the admin")
+	email := fs.String("email", "", "email address (required when creating a new user)")
+	password := fs.String("password", "", "password (generated when empty)")
+	fs.Parse(args)
+
+	if *username == "" {
+		return fmt.Errorf("-username is required")
+	}
+
+	db, err := database.NewDatabase(cfg)
+	if err != nil {
+		return err
+	}
+
+	// 서버를 처음 띄우기 전에 실행할 수 있도록 스키마를 먼저 준비
+	if cfg.Database.AutoMigrate {
+		if err := database.DBAutoMigrate(db); err != nil {
+			return err
+		}
+	}
+
+	authService, err := service.NewAuthService(db, cfg.Auth, events.NoopPublisher{})
+	if err != nil {
+		return err
+	}
+
+	generated := false
+	if *password == "" {
+		secret := make([]byte, 18)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+		*password = base64.RawURLEncoding.EncodeToString(secret)
+		generated = true
+	}
+
+	user, created, err := service.NewAdminService(db, authService).BootstrapAdmin(*username, *email, *password)
+	if err != nil {
+		if errs.Is(err, errors.ErrInvalidRequest) {
+			return fmt.Errorf("-email is required to create a new user, and bots cannot be admins: %w", err)
+		}
+		return err
+	}
+
+	if !created {
+		fmt.Printf("promoted user %d (%s) to admin\n", user.ID, user.Username)
+		return nil
+	}
+	fmt.Printf("created admin %d (%s)\n", user.ID, user.Username)
+	if generated {
+		fmt.Printf("password: %s\n", *password)
+	}
+	return nil
+}
+
+// revokeSessions는 사용자의 모든 토큰을 폐기하고, 모든 인스턴스에 열린 소켓을 닫도록 알립니다
+func revokeSessions(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("revoke-sessions", flag.ExitOnError)
+	userFlag := fs.String("user", "", "user ID or username")
+	fs.Parse(args)
+
+	if *userFlag == "" {
+		return fmt.Errorf("-user is required")
+	}
+
+	db, err := database.NewDatabase(cfg)
+	if err != nil {
+		return err
+	}
+
+	user, err := findUser(db, *userFlag)
+	if err != nil {
+		return err
+	}
+
+	authService, err := service.NewAuthService(db, cfg.Auth, events.NoopPublisher{})
+	if err != nil {
+		return err
+	}
+
+	producer, err := kafka.NewProducer(cfg.Kafka, nil)
+	if err != nil {
+		log.Printf("warning: kafka unavailable, live connections will not be closed: %v", err)
+	} else {
+		defer producer.Close()
+		authService.OnRevoke(func(rev service.Revocation) {
+			if err := handler.PublishRevocation(producer, rev); err != nil {
+				log.Printf("warning: failed to publish revocation: %v", err)
+			}
+		})
+	}
+
+	if err := authService.RevokeAllSessions(user.ID); err != nil {
+		return err
+	}
+
+	if producer != nil {
+		if remaining := producer.Flush(10 * time.Second); remaining > 0 {
+			log.Printf("warning: %d revocation messages were not delivered", remaining)
+		}
+	}
+
+	fmt.Printf("revoked all sessions of user %d (%s)\n", user.ID, user.Username)
+	return nil
+}
+
+// findUser는 ID 또는 사용자 이름으로 사용자를 조회합니다
+func findUser(db *gorm.DB, ident string) (*models.User, error) {
+	var user models.User
+	query := db.Where("username = ?", ident)
+	if id, err := strconv.ParseUint(ident, 10, 32); err == nil {
+		query = db.Where("id = ?", id)
+	}
+
+	if err := query.First(&user).Error; err != nil {
+		return nil, fmt.Errorf("user %q not found: %w", ident, err)
+	}
+	return &user, nil
+}
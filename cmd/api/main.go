@@ -6,11 +6,13 @@ import (
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 	"mult-working/internal/config"
+	"mult-working/internal/events"
 	"mult-working/internal/handler"
 	"mult-working/internal/middleware"
 	"mult-working/internal/service"
 	"mult-working/pkg/database"
 	"mult-working/pkg/kafka"
+	"mult-working/pkg/mailer"
 )
 
 func main() {
@@ -20,8 +22,12 @@ func main() {
 			config.LoadConfig,
 			database.NewDatabase,
 			kafka.NewClient,
+			events.NewKafkaPublisher,
 			newGinEngine,
 			newAuthService,
+			newOIDCService,
+			mailer.New,
+			newAccountService,
 			handler.NewHandler,
 		),
 		// 애플리케이션 시작
@@ -38,8 +44,16 @@ func newGinEngine() *gin.Engine {
 	return r
 }
 
-func newAuthService(cfg *config.Config, db *gorm.DB) *service.AuthService {
-	return service.NewAuthService(db, []byte(cfg.Auth.SymmetricKey), cfg.Auth.TokenDuration)
+func newAuthService(cfg *config.Config, db *gorm.DB, publisher events.Publisher) (*service.AuthService, error) {
+	return service.NewAuthService(db, cfg.Auth, publisher)
+}
+
+func newOIDCService(cfg *config.Config, db *gorm.DB, authService *service.AuthService, publisher events.Publisher) (*service.OIDCService, error) {
+	return service.NewOIDCService(db, cfg.Auth.OIDC, authService, publisher)
+}
+
+func newAccountService(cfg *config.Config, db *gorm.DB, authService *service.AuthService, m mailer.Mailer) *service.AccountService {
+	return service.NewAccountService(db, cfg.Auth, authService, m)
 }
 
 type HandlerParams struct {
@@ -66,6 +80,11 @@ func startServer(p HandlerParams) {
 
 				// 라우트 설정
 				p.Handler.SetupRoutes(p.Router)
+
+				// Kafka 메시지 소비 시작
+				if err := p.Kafka.GetConsumer().Start(ctx); err != nil {
+					return err
+				}
 				// 정적 파일 제공 (프로덕션 환경에서 클라이언트 앱 서빙)
 				/*p.Router.Static("/assets", "./client/dist/assets")
 				p.Router.StaticFile("/", "./client/dist/index.html")
@@ -79,6 +98,16 @@ func startServer(p HandlerParams) {
 				return nil
 			},
 			OnStop: func(ctx context.Context) error {
+				// 이 인스턴스의 접속 상태 정리
+				if err := p.Handler.Close(); err != nil {
+					return err
+				}
+
+				// 처리 중인 메시지를 마무리하고 오프셋 커밋
+				if err := p.Kafka.GetConsumer().Stop(ctx); err != nil {
+					return err
+				}
+
 				// Kafka 클라이언트 정리
 				if err := p.Kafka.Close(); err != nil {
 					return err
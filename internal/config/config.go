@@ -13,6 +13,7 @@ type Config struct {
 	Database DatabaseConfig
 	Kafka    KafkaConfig
 	Auth     AuthConfig
+	Mailer   MailerConfig
 }
 
 type ServerConfig struct {
@@ -32,13 +33,190 @@ type DatabaseConfig struct {
 }
 
 type KafkaConfig struct {
-	Brokers []string
-	Topic   string
+	Brokers          []string
+	Topic            string
+	EventsTopic      string                 `mapstructure:"events_topic"`
+	GroupID          string                 `mapstructure:"group_id"`
+	ClientID         string                 `mapstructure:"client_id"`
+	SecurityProtocol string                 `mapstructure:"security_protocol"`
+	SASL             KafkaSASLConfig        `mapstructure:"sasl"`
+	TLS              KafkaTLSConfig         `mapstructure:"tls"`
+	Properties       map[string]interface{} `mapstructure:"properties"`
+	Concurrency      int                    `mapstructure:"concurrency"`
+	MaxRetries       int                    `mapstructure:"max_retries"`
+	RetryBackoff     time.Duration          `mapstructure:"retry_backoff"`
+	CommitInterval   time.Duration          `mapstructure:"commit_interval"`
+	// DeadLetterTopic은 재시도를 모두 실패한 메시지를 보관할 토픽입니다. 비어 있으면 실패한 파티션의 소비를 멈춥니다
+	DeadLetterTopic string `mapstructure:"dead_letter_topic"`
+	// HealthCheckInterval은 브로커 연결 확인 주기, UnreachableThreshold는 readiness가 실패하기까지의 연결 불가 허용 시간입니다
+	HealthCheckInterval  time.Duration      `mapstructure:"health_check_interval"`
+	UnreachableThreshold time.Duration      `mapstructure:"unreachable_threshold"`
+	CircuitBreaker       KafkaBreakerConfig `mapstructure:"circuit_breaker"`
+}
+
+// KafkaBreakerConfig는 브로커 장애 시 발행을 중단하고 버퍼링하는 회로 차단기 설정입니다
+type KafkaBreakerConfig struct {
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	RetryInterval    time.Duration `mapstructure:"retry_interval"`
+	BufferSize       int           `mapstructure:"buffer_size"`
+}
+
+// KafkaSASLConfig는 SASL 인증 설정입니다
+type KafkaSASLConfig struct {
+	Mechanism string
+	Username  string
+	Password  string
+}
+
+// KafkaTLSConfig는 브로커와의 TLS 연결 설정입니다
+type KafkaTLSConfig struct {
+	CALocation         string `mapstructure:"ca_location"`
+	CertLocation       string `mapstructure:"cert_location"`
+	KeyLocation        string `mapstructure:"key_location"`
+	KeyPassword        string `mapstructure:"key_password"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
 }
 
 type AuthConfig struct {
-	SymmetricKey  string        `mapstructure:"symmetric_key"`
-	TokenDuration time.Duration `mapstructure:"token_duration"`
+	// TokenMode는 "local"(v2.local, 대칭 키) 또는 "public"(v2.public, Ed25519 서명)입니다
+	TokenMode string `mapstructure:"token_mode"`
+	// SymmetricKey는 폐기 예정입니다. 설정되어 있으면 키 ID가 없는 이전 토큰을 검증하는 데만 쓰고, 다음 릴리스에서 제거합니다
+	SymmetricKey string `mapstructure:"symmetric_key"`
+	// DevMode는 server.mode가 debug인지 나타냅니다. 개발 모드에서는 키가 없으면 임시 키를 생성합니다 (설정 파일이 아닌 Server.Mode에서 채움)
+	DevMode bool `mapstructure:"-"`
+	// Keys는 토큰 키 목록입니다. ActiveKeyID 키로 발급하고, 목록의 모든 키로 검증합니다
+	Keys                 []AuthKeyConfig `mapstructure:"keys"`
+	ActiveKeyID          string          `mapstructure:"active_key_id"`
+	TokenDuration        time.Duration   `mapstructure:"token_duration"`
+	RefreshTokenDuration time.Duration   `mapstructure:"refresh_token_duration"`
+	OIDC                 OIDCConfig      `mapstructure:"oidc"`
+	// RequireVerifiedEmail이 true면 이메일 인증을 마치기 전에는 로그인할 수 없습니다
+	RequireVerifiedEmail bool `mapstructure:"require_verified_email"`
+	// VerificationTokenDuration과 PasswordResetTokenDuration은 메일로 보내는 일회용 토큰의 유효 시간입니다
+	VerificationTokenDuration  time.Duration `mapstructure:"verification_token_duration"`
+	PasswordResetTokenDuration time.Duration `mapstructure:"password_reset_token_duration"`
+	// LinkBaseURL은 메일에 담기는 링크의 기준 URL(클라이언트 주소)입니다
+	LinkBaseURL string `mapstructure:"link_base_url"`
+	// TOTPIssuer는 인증 앱에 표시되는 서비스 이름입니다
+	TOTPIssuer string `mapstructure:"totp_issuer"`
+	// TwoFactorChallengeDuration은 비밀번호 확인 후 2단계 코드를 입력할 수 있는 시간입니다
+	TwoFactorChallengeDuration time.Duration       `mapstructure:"two_factor_challenge_duration"`
+	LoginThrottle              LoginThrottleConfig `mapstructure:"login_throttle"`
+	// WSTicketDuration은 웹소켓 연결 티켓의 유효 시간입니다
+	WSTicketDuration time.Duration `mapstructure:"ws_ticket_duration"`
+	// AllowWSQueryToken은 폐기 예정입니다. true면 이전 클라이언트처럼 ?token=<액세스 토큰>으로도 웹소켓에 연결할 수 있고, 다음 릴리스에서 제거합니다
+	AllowWSQueryToken bool `mapstructure:"allow_ws_query_token"`
+	// GuestDuration은 공유 링크로 들어온 게스트 계정의 최대 유지 시간입니다 (링크 만료가 더 빠르면 그때까지)
+	GuestDuration time.Duration  `mapstructure:"guest_duration"`
+	Password      PasswordConfig `mapstructure:"password"`
+	// Authenticators는 비밀번호 로그인에 사용할 인증 백엔드를 시도할 순서대로 나열합니다 ("database", "ldap")
+	Authenticators []string   `mapstructure:"authenticators"`
+	LDAP           LDAPConfig `mapstructure:"ldap"`
+}
+
+// LDAPConfig는 LDAP 디렉터리 인증 설정입니다.
+// 서비스 계정(BindDN)으로 사용자를 검색한 뒤, 찾은 항목의 DN과 입력한 비밀번호로 다시 바인드해 확인합니다
+type LDAPConfig struct {
+	// URL은 ldap://host:389 또는 ldaps://host:636 형식입니다
+	URL                string        `mapstructure:"url"`
+	StartTLS           bool          `mapstructure:"start_tls"`
+	InsecureSkipVerify bool          `mapstructure:"insecure_skip_verify"`
+	Timeout            time.Duration `mapstructure:"timeout"`
+	BindDN             string        `mapstructure:"bind_dn"`
+	BindPassword       string        `mapstructure:"bind_password"`
+	BaseDN             string        `mapstructure:"base_dn"`
+	// UserFilter는 사용자 검색 필터입니다. %s 자리에 이스케이프된 사용자 이름이 들어갑니다
+	UserFilter        string `mapstructure:"user_filter"`
+	UsernameAttribute string `mapstructure:"username_attribute"`
+	EmailAttribute    string `mapstructure:"email_attribute"`
+	// GroupAttribute는 사용자가 속한 그룹 DN 목록 속성입니다 (보통 memberOf)
+	GroupAttribute string `mapstructure:"group_attribute"`
+	// GroupRoles는 그룹을 시스템 역할로 바꾸는 규칙입니다. 여러 그룹에 속하면 가장 높은 역할을 부여하고,
+	// 규칙이 하나라도 있으면 로그인할 때마다 디렉터리에 맞춰 역할을 갱신합니다
+	GroupRoles []LDAPGroupRole `mapstructure:"group_roles"`
+}
+
+// LDAPGroupRole은 LDAP 그룹 DN 하나와 그 그룹에 부여할 역할입니다
+type LDAPGroupRole struct {
+	Group string `mapstructure:"group"`
+	Role  string `mapstructure:"role"`
+}
+
+// PasswordConfig는 비밀번호 해싱과 정책 설정입니다
+type PasswordConfig struct {
+	Argon2    Argon2Config `mapstructure:"argon2"`
+	MinLength int          `mapstructure:"min_length"`
+	MaxLength int          `mapstructure:"max_length"`
+	// BlocklistFile은 사용할 수 없는 흔한 비밀번호 목록 파일입니다 (한 줄에 하나)
+	BlocklistFile string `mapstructure:"blocklist_file"`
+}
+
+// Argon2Config는 Argon2id 파라미터입니다. 값을 바꾸면 기존 해시는 다음 로그인 때 새 파라미터로 다시 해시됩니다
+type Argon2Config struct {
+	Memory      uint32 `mapstructure:"memory"` // KiB
+	Iterations  uint32 `mapstructure:"iterations"`
+	Parallelism uint8  `mapstructure:"parallelism"`
+	SaltLength  uint32 `mapstructure:"salt_length"`
+	KeyLength   uint32 `mapstructure:"key_length"`
+}
+
+// LoginThrottleConfig는 로그인 시도 제한 설정입니다. 실패는 Window 안의 기록만 셉니다
+type LoginThrottleConfig struct {
+	Window time.Duration `mapstructure:"window"`
+	// FreeAttempts번 실패할 때까지는 지연 없이 다시 시도할 수 있습니다
+	FreeAttempts int `mapstructure:"free_attempts"`
+	// 그 이후에는 실패할 때마다 BaseDelay부터 두 배씩 늘어나는 대기 시간(최대 MaxDelay)이 적용됩니다
+	BaseDelay time.Duration `mapstructure:"base_delay"`
+	MaxDelay  time.Duration `mapstructure:"max_delay"`
+	// 계정 하나에 MaxAccountFailures번 실패하면 LockoutDuration 동안 잠깁니다
+	MaxAccountFailures int           `mapstructure:"max_account_failures"`
+	LockoutDuration    time.Duration `mapstructure:"lockout_duration"`
+	// IP 하나에서 Window 안에 MaxIPFailures번 실패하면 해당 IP의 로그인을 막습니다
+	MaxIPFailures int `mapstructure:"max_ip_failures"`
+}
+
+// AuthKeyConfig는 토큰 키 하나입니다. 모드에 따라 Secret 또는 PrivateKey/PublicKey를 사용합니다
+type AuthKeyConfig struct {
+	ID string `mapstructure:"id"`
+	// Secret은 local 모드의 base64 32바이트 대칭 키입니다
+	Secret string `mapstructure:"secret"`
+	// PrivateKey는 public 모드의 base64 Ed25519 시드(32바이트) 또는 개인 키(64바이트)입니다
+	PrivateKey string `mapstructure:"private_key"`
+	// PublicKey는 public 모드에서 검증에만 쓰는 이전 키의 base64 공개 키입니다
+	PublicKey string `mapstructure:"public_key"`
+}
+
+// OIDCConfig는 OpenID Connect 로그인 설정입니다
+type OIDCConfig struct {
+	// StateTTL은 로그인 시작부터 콜백까지 허용하는 시간입니다
+	StateTTL  time.Duration        `mapstructure:"state_ttl"`
+	Providers []OIDCProviderConfig `mapstructure:"providers"`
+}
+
+// OIDCProviderConfig는 OIDC 공급자 하나의 설정입니다. Name은 로그인 URL 경로에 사용됩니다
+type OIDCProviderConfig struct {
+	Name         string   `mapstructure:"name"`
+	IssuerURL    string   `mapstructure:"issuer_url"`
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	RedirectURL  string   `mapstructure:"redirect_url"`
+	Scopes       []string `mapstructure:"scopes"`
+}
+
+// MailerConfig는 메일 발송 설정입니다
+type MailerConfig struct {
+	// Driver는 "smtp", "log"(로그 출력), "file"(Directory에 .eml 저장) 중 하나입니다
+	Driver    string     `mapstructure:"driver"`
+	From      string     `mapstructure:"from"`
+	Directory string     `mapstructure:"directory"`
+	SMTP      SMTPConfig `mapstructure:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
 }
 
 var ServerInstanceID = fmt.Sprintf("server-%s-%d", "MULTIPROCESS", time.Now().UnixNano())
@@ -64,6 +242,7 @@ func LoadConfig() (*Config, error) {
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, err
 	}
+	config.Auth.DevMode = config.Server.Mode == "debug"
 
 	return &config, nil
 }
@@ -81,6 +260,7 @@ func LoadConfigByEnv(env string) (*Config, error) {
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, err
 	}
+	config.Auth.DevMode = config.Server.Mode == "debug"
 
 	return &config, nil
 }
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// AdminUserResponse는 관리자용 사용자 응답 DTO입니다
+type AdminUserResponse struct {
+	ID            uint      `json:"id"`
+	Username      string    `json:"username"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	IsBot         bool      `json:"isBot"`
+	IsGuest       bool      `json:"isGuest"`
+	EmailVerified bool      `json:"emailVerified"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// UpdateRoleRequest는 사용자 역할 변경 요청 DTO입니다
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user moderator admin"`
+}
@@ -6,7 +6,7 @@ import "time"
 type RegisterRequest struct {
 	Username string `json:"username" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
+	Password string `json:"password" binding:"required"`
 }
 
 type LoginRequest struct {
@@ -14,9 +14,55 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type EmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
 // Auth 관련 응답 DTO
 type TokenResponse struct {
-	Token     string    `json:"token"`
+	Token            string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// LoginResponse는 로그인 결과입니다. 2단계 인증이 필요하면 토큰 대신 챌린지 토큰이 담깁니다
+type LoginResponse struct {
+	*TokenResponse
+	TwoFactorRequired  bool       `json:"two_factor_required,omitempty"`
+	ChallengeToken     string     `json:"challenge_token,omitempty"`
+	ChallengeExpiresAt *time.Time `json:"challenge_expires_at,omitempty"`
+}
+
+// WSTicketResponse는 웹소켓 연결 티켓 응답입니다
+type WSTicketResponse struct {
+	Ticket    string    `json:"ticket"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
@@ -25,3 +71,14 @@ type UserResponse struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 }
+
+// SessionResponse는 로그인 세션(기기) 응답입니다. Current는 요청에 사용된 세션인지 나타냅니다
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// CreateBotRequest는 봇 사용자 생성 요청 DTO입니다
+type CreateBotRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=50"`
+}
+
+// BotResponse는 봇 사용자 응답 DTO입니다
+type BotResponse struct {
+	ID        uint      `json:"id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreateAPIKeyRequest는 API 키 발급 요청 DTO입니다. ExpiresInDays가 0이면 만료되지 않습니다
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays int      `json:"expiresInDays" binding:"min=0,max=3650"`
+}
+
+// APIKeyResponse는 API 키 응답 DTO입니다. 키 원문은 포함하지 않습니다
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// CreatedAPIKeyResponse는 발급 직후의 API 키 응답 DTO입니다. 키 원문은 이때 한 번만 반환됩니다
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
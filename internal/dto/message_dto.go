@@ -14,6 +14,7 @@ type MessageResponse struct {
 	Content   string    `json:"content"`
 	UserID    uint      `json:"userId"`
 	Username  string    `json:"username"`
+	IsGuest   bool      `json:"isGuest,omitempty"`
 	RoomID    uint      `json:"roomId"`
 	CreatedAt time.Time `json:"createdAt"`
 }
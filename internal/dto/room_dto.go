@@ -6,19 +6,148 @@ import "time"
 type CreateRoomRequest struct {
 	Name        string `json:"name" binding:"required,min=3,max=100"`
 	Description string `json:"description" binding:"max=500"`
+	// Visibility를 생략하면 공개 채팅방이 됩니다
+	Visibility string `json:"visibility" binding:"omitempty,oneof=public invite_only private"`
 }
 
 // RoomResponse는 채팅방 응답 DTO입니다
 type RoomResponse struct {
-	ID          uint      `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CreatedBy   uint      `json:"createdBy"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UserCount   int       `json:"userCount"`
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Visibility  string     `json:"visibility"`
+	Topic       string     `json:"topic"`
+	ArchivedAt  *time.Time `json:"archivedAt"`
+	CreatedBy   uint       `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UserCount   int        `json:"userCount"`
 }
 
-// JoinRoomRequest는 채팅방 참여 요청 DTO입니다
+// UpdateRoomRequest는 채팅방 설정 변경 요청 DTO입니다. 보낸 필드만 바뀝니다
+type UpdateRoomRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=3,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	Topic       *string `json:"topic" binding:"omitempty,max=250"`
+}
+
+// TransferOwnershipRequest는 채팅방 소유권 이전 요청 DTO입니다
+type TransferOwnershipRequest struct {
+	UserID uint `json:"userId" binding:"required"`
+}
+
+// JoinRoomRequest는 채팅방 참여 요청 DTO입니다.
+// 초대가 필요한 채팅방은 초대 코드가 필요하며, 초대 코드만 보내면 그 코드의 채팅방에 참여합니다
 type JoinRoomRequest struct {
-	RoomID uint `json:"roomId" binding:"required"`
+	RoomID     uint   `json:"roomId" binding:"required_without=InviteCode"`
+	InviteCode string `json:"inviteCode"`
+}
+
+// UpdateMemberRoleRequest는 채팅방 멤버 역할 변경 요청 DTO입니다. 소유자는 소유권 이전으로만 바뀝니다
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin moderator member read_only"`
+}
+
+// RoomMemberResponse는 채팅방 멤버 응답 DTO입니다
+type RoomMemberResponse struct {
+	UserID   uint      `json:"userId"`
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+	IsGuest  bool      `json:"isGuest,omitempty"`
+	JoinedAt time.Time `json:"joinedAt"`
+	// Online은 어느 서버 인스턴스에든 웹소켓으로 접속해 있는지 나타냅니다 (멤버 목록에서만 채움)
+	Online bool `json:"online"`
+}
+
+// RoomMemberListResponse는 채팅방 멤버 목록 응답 DTO입니다.
+// NextCursor가 비어 있으면 마지막 페이지입니다
+type RoomMemberListResponse struct {
+	Members    []RoomMemberResponse `json:"members"`
+	NextCursor string               `json:"nextCursor,omitempty"`
+}
+
+// KickMemberRequest는 채팅방 멤버 내보내기 요청 DTO입니다
+type KickMemberRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// BanMemberRequest는 채팅방 차단 요청 DTO입니다. DurationHours를 생략하면 해제할 때까지 차단합니다
+type BanMemberRequest struct {
+	Reason        string `json:"reason" binding:"max=500"`
+	DurationHours int    `json:"durationHours" binding:"omitempty,min=1,max=8760"`
+}
+
+// RoomBanResponse는 채팅방 차단 응답 DTO입니다
+type RoomBanResponse struct {
+	RoomID    uint       `json:"roomId"`
+	UserID    uint       `json:"userId"`
+	Username  string     `json:"username"`
+	BannedBy  uint       `json:"bannedBy"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// CreateInviteRequest는 채팅방 초대 코드 생성 요청 DTO입니다.
+// 만료 시간과 사용 횟수를 생략하면 제한이 없고, InviteeID를 주면 그 사용자만 쓸 수 있습니다
+type CreateInviteRequest struct {
+	InviteeID      *uint `json:"inviteeId" binding:"omitempty,min=1"`
+	MaxUses        int   `json:"maxUses" binding:"omitempty,min=1,max=1000"`
+	ExpiresInHours int   `json:"expiresInHours" binding:"omitempty,min=1,max=720"`
+}
+
+// InviteResponse는 초대 코드 응답 DTO입니다. 초대 코드는 포함하지 않습니다
+type InviteResponse struct {
+	ID        uint       `json:"id"`
+	RoomID    uint       `json:"roomId"`
+	CreatedBy uint       `json:"createdBy"`
+	InviteeID *uint      `json:"inviteeId"`
+	MaxUses   int        `json:"maxUses"`
+	Uses      int        `json:"uses"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// CreatedInviteResponse는 새로 만든 초대 코드 응답입니다. 초대 코드는 이때 한 번만 반환됩니다
+type CreatedInviteResponse struct {
+	InviteResponse
+	Code string `json:"code"`
+}
+
+// CreateShareLinkRequest는 게스트 공유 링크 생성 요청 DTO입니다
+type CreateShareLinkRequest struct {
+	CanWrite       bool `json:"canWrite"`
+	ExpiresInHours int  `json:"expiresInHours" binding:"required,min=1,max=720"`
+}
+
+// ShareLinkResponse는 공유 링크 응답 DTO입니다. 링크 토큰은 포함하지 않습니다
+type ShareLinkResponse struct {
+	ID        uint       `json:"id"`
+	RoomID    uint       `json:"roomId"`
+	CanWrite  bool       `json:"canWrite"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// CreatedShareLinkResponse는 새로 만든 공유 링크 응답입니다. 링크 토큰은 이때 한 번만 반환됩니다
+type CreatedShareLinkResponse struct {
+	ShareLinkResponse
+	Token string `json:"token"`
+}
+
+// GuestJoinRequest는 공유 링크로 게스트 입장하는 요청 DTO입니다
+type GuestJoinRequest struct {
+	Token       string `json:"token" binding:"required"`
+	DisplayName string `json:"displayName" binding:"required,min=2,max=32"`
+}
+
+// GuestJoinResponse는 게스트 입장 응답 DTO입니다. 게스트 토큰은 리프레시할 수 없고 게스트 계정과 함께 만료됩니다
+type GuestJoinResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	UserID    uint      `json:"userId"`
+	Username  string    `json:"username"`
+	RoomID    uint      `json:"roomId"`
+	CanWrite  bool      `json:"canWrite"`
 }
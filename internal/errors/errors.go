@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -16,15 +17,53 @@ var (
 	ErrUserExists         = errors.New("user already exists")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenExpired       = errors.New("token expired")
+	ErrTokenReused        = errors.New("refresh token reuse detected")
+	ErrTokenRevoked       = errors.New("token revoked")
 	ErrPermissionDenied   = errors.New("permission denied")
 	ErrInvalidRequest     = errors.New("invalid request")
 	ErrDatabaseError      = errors.New("database error")
 	ErrRoomNotFound       = errors.New("room not found")
 	ErrAlreadyJoined      = errors.New("already joined the room")
 	ErrNotJoined          = errors.New("not joined the room")
-	ErrCannotLeave        = errors.New("creator cannot leave the room")
+	ErrCannotLeave        = errors.New("owner cannot leave the room, transfer ownership first")
+	ErrProviderNotFound   = errors.New("identity provider not found")
+	ErrInvalidLoginState  = errors.New("invalid or expired login state")
+	ErrEmailNotVerified   = errors.New("email not verified")
+	ErrInvalidTwoFactor   = errors.New("invalid two-factor code")
+	ErrTwoFactorEnabled   = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorDisabled  = errors.New("two-factor authentication not enabled")
+	ErrTooManyAttempts    = errors.New("too many attempts, try again later")
+	ErrInvalidAPIKey      = errors.New("invalid api key")
+	ErrInsufficientScope  = errors.New("api key lacks the required scope")
+	ErrBotNotFound        = errors.New("bot not found")
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrLastAdmin          = errors.New("cannot remove the last admin")
+	ErrWeakPassword       = errors.New("password does not meet the policy")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrShareLinkNotFound  = errors.New("share link not found or expired")
+	ErrAuthUnavailable    = errors.New("authentication backend unavailable")
+	ErrInviteRequired     = errors.New("an invitation is required to join this room")
+	ErrInviteNotFound     = errors.New("invitation not found, expired or used up")
+	ErrMemberNotFound     = errors.New("room member not found")
+	ErrMessageNotFound    = errors.New("message not found")
+	ErrBanned             = errors.New("banned from this room")
+	ErrBanNotFound        = errors.New("ban not found")
+	ErrRoomArchived       = errors.New("room is archived")
 )
 
+// ThrottledError는 시도 횟수 제한에 걸렸을 때 반환되며 다시 시도할 수 있을 때까지의 시간을 담습니다
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return ErrTooManyAttempts.Error()
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
 // AppError는 애플리케이션 에러를 표현합니다
 type AppError struct {
 	Err        error
@@ -45,30 +84,59 @@ func MapError(err error) AppError {
 			StatusCode: http.StatusUnauthorized,
 			Message:    err.Error(),
 		}
-	case errors.Is(err, ErrUserExists):
+	case errors.Is(err, ErrUserExists), errors.Is(err, ErrTwoFactorEnabled), errors.Is(err, ErrLastAdmin),
+		errors.Is(err, ErrCannotLeave), errors.Is(err, ErrRoomArchived), errors.Is(err, ErrAlreadyJoined):
 		return AppError{
 			Err:        err,
 			StatusCode: http.StatusConflict,
 			Message:    err.Error(),
 		}
-	case errors.Is(err, ErrInvalidToken), errors.Is(err, ErrTokenExpired):
+	case errors.Is(err, ErrInvalidToken), errors.Is(err, ErrTokenExpired), errors.Is(err, ErrTokenReused), errors.Is(err, ErrTokenRevoked):
 		return AppError{
 			Err:        err,
 			StatusCode: http.StatusUnauthorized,
 			Message:    err.Error(),
 		}
-	case errors.Is(err, ErrPermissionDenied):
+	case errors.Is(err, ErrInvalidLoginState), errors.Is(err, ErrInvalidTwoFactor), errors.Is(err, ErrInvalidAPIKey):
+		return AppError{
+			Err:        err,
+			StatusCode: http.StatusUnauthorized,
+			Message:    err.Error(),
+		}
+	case errors.Is(err, ErrProviderNotFound), errors.Is(err, ErrBotNotFound), errors.Is(err, ErrAPIKeyNotFound),
+		errors.Is(err, ErrSessionNotFound), errors.Is(err, ErrShareLinkNotFound),
+		errors.Is(err, ErrInviteNotFound), errors.Is(err, ErrMemberNotFound), errors.Is(err, ErrMessageNotFound),
+		errors.Is(err, ErrBanNotFound), errors.Is(err, ErrRoomNotFound):
+		return AppError{
+			Err:        err,
+			StatusCode: http.StatusNotFound,
+			Message:    err.Error(),
+		}
+	case errors.Is(err, ErrPermissionDenied), errors.Is(err, ErrEmailNotVerified), errors.Is(err, ErrInsufficientScope),
+		errors.Is(err, ErrInviteRequired), errors.Is(err, ErrBanned), errors.Is(err, ErrNotJoined):
 		return AppError{
 			Err:        err,
 			StatusCode: http.StatusForbidden,
 			Message:    err.Error(),
 		}
-	case errors.Is(err, ErrInvalidRequest):
+	case errors.Is(err, ErrInvalidRequest), errors.Is(err, ErrTwoFactorDisabled), errors.Is(err, ErrWeakPassword):
 		return AppError{
 			Err:        err,
 			StatusCode: http.StatusBadRequest,
 			Message:    err.Error(),
 		}
+	case errors.Is(err, ErrTooManyAttempts):
+		return AppError{
+			Err:        err,
+			StatusCode: http.StatusTooManyRequests,
+			Message:    err.Error(),
+		}
+	case errors.Is(err, ErrAuthUnavailable):
+		return AppError{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+			Message:    err.Error(),
+		}
 	case errors.Is(err, ErrDatabaseError):
 		return AppError{
 			Err:        err,
@@ -0,0 +1,145 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"mult-working/internal/config"
+)
+
+// 도메인 이벤트 타입
+const (
+	TypeUserRegistered    = "user.registered"
+	TypeRoomCreated       = "room.created"
+	TypeRoomUpdated       = "room.updated"
+	TypeRoomDeleted       = "room.deleted"
+	TypeRoomMemberJoined  = "room.member_joined"
+	TypeRoomMemberLeft    = "room.member_left"
+	TypeRoomMemberRemoved = "room.member_removed"
+	TypeRoomRoleChanged   = "room.role_changed"
+	TypeMessageCreated    = "message.created"
+)
+
+// Event는 이벤트 토픽으로 발행되는 공통 봉투(envelope)입니다.
+// 스키마는 schemas 디렉터리의 <type>.v<version>.json 파일에 정의되어 있습니다.
+//
+// 호환성 규칙: 같은 버전에서는 선택(omitempty) 필드 추가만 허용하며, 스키마에 선택 필드로 표시하고
+// 없을 때의 의미를 적습니다. 컨슈머는 모르는 필드를 무시해야 합니다.
+// 필드 삭제, 이름이나 타입 변경, 필수 필드 추가는 버전을 올리고, 전환 기간 동안 이전 버전도 함께 발행합니다
+type Event struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	Version    int         `json:"version"`
+	Key        string      `json:"key"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Source     string      `json:"source"`
+	Data       interface{} `json:"data"`
+}
+
+// UserRegistered는 user.registered 이벤트의 데이터입니다
+type UserRegistered struct {
+	UserID   uint   `json:"userId"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// RoomCreated는 room.created 이벤트의 데이터입니다
+type RoomCreated struct {
+	RoomID      uint   `json:"roomId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Visibility는 v1에 나중에 추가된 선택 필드입니다
+	Visibility string `json:"visibility,omitempty"`
+	CreatedBy  uint   `json:"createdBy"`
+}
+
+// RoomUpdated는 room.updated 이벤트의 데이터입니다 (설정 변경, 보관, 보관 해제)
+type RoomUpdated struct {
+	RoomID      uint       `json:"roomId"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Topic       string     `json:"topic"`
+	ArchivedAt  *time.Time `json:"archivedAt,omitempty"`
+	UpdatedBy   uint       `json:"updatedBy"`
+}
+
+// RoomDeleted는 room.deleted 이벤트의 데이터입니다
+type RoomDeleted struct {
+	RoomID    uint `json:"roomId"`
+	DeletedBy uint `json:"deletedBy"`
+}
+
+// RoomMemberJoined는 room.member_joined 이벤트의 데이터입니다
+type RoomMemberJoined struct {
+	RoomID uint `json:"roomId"`
+	UserID uint `json:"userId"`
+	// Guest는 공유 링크로 들어온 게스트인지 나타냅니다
+	Guest bool `json:"guest,omitempty"`
+}
+
+// RoomMemberLeft는 room.member_left 이벤트의 데이터입니다
+type RoomMemberLeft struct {
+	RoomID uint `json:"roomId"`
+	UserID uint `json:"userId"`
+}
+
+// RoomMemberRemoved는 room.member_removed 이벤트의 데이터입니다 (모더레이터가 내보내거나 차단)
+type RoomMemberRemoved struct {
+	RoomID    uint   `json:"roomId"`
+	UserID    uint   `json:"userId"`
+	RemovedBy uint   `json:"removedBy"`
+	Reason    string `json:"reason,omitempty"`
+	// Banned는 차단으로 내보냈는지 나타내며, BannedUntil이 없으면 해제할 때까지 차단입니다
+	Banned      bool       `json:"banned"`
+	BannedUntil *time.Time `json:"bannedUntil,omitempty"`
+}
+
+// RoomRoleChanged는 room.role_changed 이벤트의 데이터입니다
+type RoomRoleChanged struct {
+	RoomID    uint   `json:"roomId"`
+	UserID    uint   `json:"userId"`
+	Role      string `json:"role"`
+	ChangedBy uint   `json:"changedBy"`
+}
+
+// MessageCreated는 message.created 이벤트의 데이터입니다
+type MessageCreated struct {
+	MessageID uint   `json:"messageId"`
+	RoomID    uint   `json:"roomId"`
+	UserID    uint   `json:"userId"`
+	Content   string `json:"content"`
+}
+
+// New는 새 이벤트를 생성합니다. key는 같은 대상(방, 사용자)의 이벤트 순서를 보장하는 파티션 키입니다
+func New(eventType string, version int, key string, data interface{}) Event {
+	return Event{
+		ID:         newEventID(),
+		Type:       eventType,
+		Version:    version,
+		Key:        key,
+		OccurredAt: time.Now().UTC(),
+		Source:     config.ServerInstanceID,
+		Data:       data,
+	}
+}
+
+// RoomKey는 채팅방 이벤트의 파티션 키를 반환합니다
+func RoomKey(roomID uint) string {
+	return fmt.Sprintf("room:%d", roomID)
+}
+
+// UserKey는 사용자 이벤트의 파티션 키를 반환합니다
+func UserKey(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// newEventID는 16바이트 랜덤 ID를 생성합니다
+func newEventID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
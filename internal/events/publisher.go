@@ -0,0 +1,63 @@
+package events
+
+import (
+	"encoding/json"
+	"log"
+
+	"mult-working/internal/config"
+	"mult-working/pkg/kafka"
+)
+
+// Publisher는 도메인 이벤트 발행자 인터페이스입니다
+type Publisher interface {
+	// Publish는 이벤트를 발행합니다
+	Publish(event Event) error
+}
+
+// KafkaPublisher는 이벤트를 Kafka 이벤트 토픽으로 발행합니다
+type KafkaPublisher struct {
+	producer kafka.Producer
+	topic    string
+}
+
+// NewKafkaPublisher는 설정된 이벤트 토픽으로 발행하는 Publisher를 생성합니다
+func NewKafkaPublisher(cfg *config.Config, client kafka.KafkaInterface) Publisher {
+	topic := cfg.Kafka.EventsTopic
+	if topic == "" {
+		topic = "chat-events"
+	}
+
+	return &KafkaPublisher{
+		producer: client.GetProducer(),
+		topic:    topic,
+	}
+}
+
+// Publish는 이벤트를 JSON으로 직렬화해 이벤트의 키와 함께 발행합니다
+func (p *KafkaPublisher) Publish(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return p.producer.ProduceWithKey(p.topic, []byte(event.Key), data)
+}
+
+// NoopPublisher는 이벤트를 버리는 Publisher입니다
+type NoopPublisher struct{}
+
+// Publish는 아무 것도 하지 않습니다
+func (NoopPublisher) Publish(Event) error {
+	return nil
+}
+
+// PublishOrLog는 이벤트를 발행하고 실패하면 로그만 남깁니다.
+// 이벤트 발행 실패가 이미 커밋된 요청을 실패시키지 않도록 서비스에서 사용합니다
+func PublishOrLog(p Publisher, event Event) {
+	if p == nil {
+		return
+	}
+	if err := p.Publish(event); err != nil {
+		log.Printf("Failed to publish %s event: %v", event.Type, err)
+	}
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// Schema는 이벤트 타입과 버전에 해당하는 JSON 스키마를 반환합니다
+func Schema(eventType string, version int) ([]byte, error) {
+	return schemaFS.ReadFile(fmt.Sprintf("schemas/%s.v%d.json", eventType, version))
+}
+
+// SchemaNames는 제공되는 모든 스키마 파일 이름을 반환합니다
+func SchemaNames() ([]string, error) {
+	entries, err := schemaFS.ReadDir("schemas")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names, nil
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 주소 존재 여부를 노출하지 않도록 메일 요청에는 항상 같은 응답을 보냄
+const mailRequestAccepted = "If the address is registered, an email has been sent"
+
+func (h *Handler) registerAccountRoutes(r *gin.RouterGroup) {
+	auth := r.Group("/auth")
+	{
+		auth.POST("/verify", h.VerifyEmail)
+		auth.POST("/verify/resend", h.ResendVerification)
+		auth.POST("/password/reset/request", h.RequestPasswordReset)
+		auth.POST("/password/reset", h.ResetPassword)
+	}
+}
+
+// VerifyEmail은 메일로 받은 토큰으로 이메일 주소를 인증합니다
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountService.VerifyEmail(req.Token); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// ResendVerification은 이메일 인증 링크를 다시 보냅니다
+func (h *Handler) ResendVerification(c *gin.Context) {
+	var req dto.EmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.accountService.SendVerification(c.Request.Context(), req.Email)
+
+	c.JSON(http.StatusAccepted, gin.H{"message": mailRequestAccepted})
+}
+
+// RequestPasswordReset은 비밀번호 재설정 링크를 보냅니다
+func (h *Handler) RequestPasswordReset(c *gin.Context) {
+	var req dto.EmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.accountService.RequestPasswordReset(c.Request.Context(), req.Email)
+
+	c.JSON(http.StatusAccepted, gin.H{"message": mailRequestAccepted})
+}
+
+// ResetPassword는 재설정 토큰으로 새 비밀번호를 설정합니다. 기존 세션은 모두 로그아웃됩니다
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.accountService.ResetPassword(req.Token, req.Password); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/middleware"
+	"mult-working/internal/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerAdminRoutes는 /api/admin 라우트를 등록합니다. 조회는 모더레이터 이상, 변경은 관리자만 할 수 있습니다
+func (h *Handler) registerAdminRoutes(r *gin.RouterGroup) {
+	admin := r.Group("/admin")
+	admin.Use(
+		middleware.JWTAuthMiddleware(h.authService),
+		middleware.RequireUserToken(),
+		middleware.RequireRole(h.authService, models.RoleModerator),
+	)
+	{
+		admin.GET("/users", h.AdminListUsers)
+
+		adminOnly := middleware.RequireRole(h.authService, models.RoleAdmin)
+		admin.PUT("/users/:id/role", adminOnly, h.AdminSetRole)
+		admin.POST("/users/:id/revoke-sessions", adminOnly, h.AdminRevokeSessions)
+	}
+}
+
+// AdminListUsers는 사용자 목록을 반환합니다
+func (h *Handler) AdminListUsers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	users, err := h.adminService.ListUsers(limit, (page-1)*limit)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// AdminSetRole은 사용자의 시스템 역할을 바꿉니다
+func (h *Handler) AdminSetRole(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	user, err := h.adminService.SetRole(uint(userID), req.Role)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// AdminRevokeSessions는 사용자의 모든 세션을 폐기합니다
+func (h *Handler) AdminRevokeSessions(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	if err := h.adminService.RevokeSessions(uint(userID)); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sessions revoked successfully"})
+}
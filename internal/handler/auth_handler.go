@@ -1,9 +1,14 @@
 package handler
 
 import (
+	errs "errors"
+	"math"
 	"mult-working/internal/dto"
 	"mult-working/internal/errors"
+	"mult-working/internal/middleware"
+	"mult-working/internal/service"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,6 +18,10 @@ func (h *Handler) registerAuthRoutes(r *gin.RouterGroup) {
 	{
 		auth.POST("/register", h.Register)
 		auth.POST("/login", h.Login)
+		auth.POST("/login/2fa", h.LoginTwoFactor)
+		auth.POST("/refresh", h.Refresh)
+		auth.POST("/logout", middleware.JWTAuthMiddleware(h.authService), middleware.RequireToken(), h.Logout)
+		auth.GET("/keys", h.PublicKeys)
 	}
 }
 
@@ -29,6 +38,9 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
+	// 인증 메일은 백그라운드에서 발송하며, 실패해도 가입은 유지됨 (재발송 가능)
+	h.accountService.SendVerification(c.Request.Context(), req.Email)
+
 	c.JSON(http.StatusCreated, gin.H{"message": "User registered successfully"})
 }
 
@@ -39,7 +51,26 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authService.Login(req)
+	resp, err := h.authService.Login(req, clientInfo(c))
+	if err != nil {
+		setRetryAfter(c, err)
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Refresh는 리프레시 토큰을 회전시키고 새 토큰 쌍을 반환합니다
+func (h *Handler) Refresh(c *gin.Context) {
+	var req dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authService.Refresh(req, clientInfo(c))
 	if err != nil {
 		appErr := errors.MapError(err)
 		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
@@ -48,3 +79,46 @@ func (h *Handler) Login(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// Logout은 현재 액세스 토큰과 (전달된 경우) 리프레시 토큰을 폐기합니다
+func (h *Handler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	// 본문은 선택 사항
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	claims := c.MustGet("tokenClaims").(*service.TokenClaims)
+	if err := h.authService.Logout(claims, req.RefreshToken); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// PublicKeys는 다른 서비스가 토큰을 오프라인으로 검증할 수 있도록 현재 공개 키 목록을 반환합니다
+func (h *Handler) PublicKeys(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": h.authService.PublicKeys()})
+}
+
+// clientInfo는 감사 기록과 시도 횟수 제한에 사용할 클라이언트 정보를 반환합니다
+func clientInfo(c *gin.Context) service.ClientInfo {
+	return service.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// setRetryAfter는 시도 횟수 제한에 걸린 경우 Retry-After 헤더를 설정합니다
+func setRetryAfter(c *gin.Context, err error) {
+	var throttled *errors.ThrottledError
+	if errs.As(err, &throttled) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	}
+}
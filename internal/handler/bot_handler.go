@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/middleware"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) registerBotRoutes(r *gin.RouterGroup) {
+	bots := r.Group("/bots", middleware.RequireUserToken())
+	{
+		bots.GET("", h.ListBots)
+		bots.POST("", h.CreateBot)
+		bots.DELETE("/:id", h.DeleteBot)
+		bots.GET("/:id/keys", h.ListAPIKeys)
+		bots.POST("/:id/keys", h.CreateAPIKey)
+		bots.DELETE("/:id/keys/:keyId", h.RevokeAPIKey)
+	}
+}
+
+// CreateBot은 현재 사용자가 관리하는 봇 사용자를 만듭니다
+func (h *Handler) CreateBot(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req dto.CreateBotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	bot, err := h.authService.APIKeys().CreateBot(userID.(uint), req)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bot)
+}
+
+// ListBots는 현재 사용자가 관리하는 봇 목록을 반환합니다
+func (h *Handler) ListBots(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	bots, err := h.authService.APIKeys().ListBots(userID.(uint))
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, bots)
+}
+
+// DeleteBot은 봇을 삭제하고 봇의 API 키를 모두 폐기합니다
+func (h *Handler) DeleteBot(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	botID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	if err := h.authService.APIKeys().DeleteBot(userID.(uint), uint(botID)); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bot deleted successfully"})
+}
+
+// CreateAPIKey는 봇의 API 키를 발급합니다. 키 원문은 이 응답에서만 볼 수 있습니다
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	botID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	key, err := h.authService.APIKeys().CreateKey(userID.(uint), uint(botID), req)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// ListAPIKeys는 봇의 API 키 목록을 반환합니다
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	botID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	keys, err := h.authService.APIKeys().ListKeys(userID.(uint), uint(botID))
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// RevokeAPIKey는 봇의 API 키를 폐기합니다
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	botID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+	keyID, err := strconv.ParseUint(c.Param("keyId"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	if err := h.authService.APIKeys().RevokeKey(userID.(uint), uint(botID), uint(keyID)); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked successfully"})
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) registerEventRoutes(r *gin.RouterGroup) {
+	schemas := r.Group("/events/schemas")
+	{
+		schemas.GET("", h.ListEventSchemas)
+		schemas.GET("/:name", h.GetEventSchema)
+	}
+}
+
+// ListEventSchemas는 제공되는 도메인 이벤트 스키마 목록을 반환합니다
+func (h *Handler) ListEventSchemas(c *gin.Context) {
+	names, err := events.SchemaNames()
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, names)
+}
+
+// GetEventSchema는 "<type>.v<version>.json" 형식의 이름으로 이벤트 스키마를 반환합니다
+func (h *Handler) GetEventSchema(c *gin.Context) {
+	name := strings.TrimSuffix(c.Param("name"), ".json")
+	idx := strings.LastIndex(name, ".v")
+	if idx < 0 {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	version, err := strconv.Atoi(name[idx+2:])
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	schema, err := events.Schema(name[:idx], version)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "schema not found"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/schema+json", schema)
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"log"
 	"mult-working/internal/errors"
+	"mult-working/internal/events"
 	"mult-working/internal/middleware"
+	"mult-working/internal/models"
 	"mult-working/internal/service"
 	"mult-working/pkg/kafka"
 
@@ -14,6 +17,10 @@ type Handler struct {
 	db               *gorm.DB
 	kafka            kafka.KafkaInterface
 	authService      *service.AuthService
+	oidcService      *service.OIDCService
+	accountService   *service.AccountService
+	adminService     *service.AdminService
+	guestService     *service.GuestService
 	roomService      *service.RoomService
 	messageService   *service.MessageService
 	roomHandler      *RoomHandler
@@ -21,18 +28,36 @@ type Handler struct {
 	webSocketHandler *WebSocketHandler
 }
 
-func NewHandler(db *gorm.DB, kafka kafka.KafkaInterface, authService *service.AuthService) *Handler {
-	roomService := service.NewRoomService(db)
-	messageService := service.NewMessageService(db)
+func NewHandler(db *gorm.DB, kafka kafka.KafkaInterface, authService *service.AuthService, oidcService *service.OIDCService, accountService *service.AccountService, publisher events.Publisher) *Handler {
+	presence := service.NewPresenceStore(db)
+	roomService := service.NewRoomService(db, publisher, presence)
+	messageService := service.NewMessageService(db, publisher)
 
 	roomHandler := NewRoomHandler(roomService)
 	messageHandler := NewMessageHandler(messageService)
-	webSocketHandler := NewWebSocketHandler(messageService, authService, kafka.GetProducer(), kafka.GetConsumer())
+	webSocketHandler := NewWebSocketHandler(messageService, authService, presence, kafka.GetProducer(), kafka.GetConsumer())
+
+	// 내보내거나 차단한 사용자의 소켓은 모든 인스턴스에서 채팅방 구독을 해제
+	roomService.OnMemberRemoved(webSocketHandler.handleMemberRemoval)
+	// 채팅방 설정 변경과 삭제는 채팅방 소켓에 알림
+	roomService.OnRoomUpdated(webSocketHandler.handleRoomUpdate)
+	// 삭제된 채팅방 게스트의 폐기를 캐시와 다른 인스턴스, 소켓에 알림 (DB에서는 삭제와 함께 폐기됨)
+	roomService.OnRoomUpdated(func(update service.RoomUpdate) {
+		for _, guestID := range update.RevokedGuests {
+			if err := authService.RevokeAllSessions(guestID); err != nil {
+				log.Printf("Failed to revoke guest %d of deleted room %d: %v", guestID, update.Room.ID, err)
+			}
+		}
+	})
 
 	return &Handler{
 		db:               db,
 		kafka:            kafka,
 		authService:      authService,
+		oidcService:      oidcService,
+		accountService:   accountService,
+		adminService:     service.NewAdminService(db, authService),
+		guestService:     service.NewGuestService(db, authService, publisher),
 		roomService:      roomService,
 		messageService:   messageService,
 		roomHandler:      roomHandler,
@@ -41,38 +66,93 @@ func NewHandler(db *gorm.DB, kafka kafka.KafkaInterface, authService *service.Au
 	}
 }
 
+// Close는 웹소켓 핸들러의 백그라운드 작업을 멈추고 이 인스턴스의 접속 상태를 정리합니다
+func (h *Handler) Close() error {
+	err := h.webSocketHandler.Close()
+	// 진행 중인 메일 발송 마무리
+	h.accountService.Wait()
+	return err
+}
+
 func (h *Handler) SetupRoutes(r *gin.Engine) {
+	// 헬스 체크 및 지표
+	h.registerHealthRoutes(r)
+
 	// API 라우트 설정
 	api := r.Group("/api")
 	{
 		// 공개 라우트
 		h.registerAuthRoutes(api)
+		h.registerOIDCRoutes(api)
+		h.registerAccountRoutes(api)
+		h.registerEventRoutes(api)
+		h.registerGuestRoutes(api)
+
+		// 관리자 라우트
+		h.registerAdminRoutes(api)
 
 		// 보호된 라우트
 		protected := api.Group("/protected")
 		protected.Use(middleware.JWTAuthMiddleware(h.authService))
 		{
 			protected.GET("/profile", h.GetProfile)
+			h.registerTwoFactorRoutes(protected)
+			h.registerBotRoutes(protected)
+			h.registerSessionRoutes(protected)
 
-			// 채팅방 라우트
+			// 채팅방 라우트 (API 키와 게스트 토큰은 라우트별 권한 범위 필요, 게스트는 자기 채팅방만)
+			readRooms := middleware.RequireScope(models.ScopeRoomsRead)
+			manageRooms := middleware.RequireScope(models.ScopeRoomsManage)
 			rooms := protected.Group("/rooms")
 			{
-				rooms.GET("", h.roomHandler.GetRooms)
-				rooms.POST("", h.roomHandler.CreateRoom)
-				rooms.GET("/:id", h.roomHandler.GetRoom)
-				rooms.POST("/join", h.roomHandler.JoinRoom)
-				rooms.DELETE("/:id/leave", h.roomHandler.LeaveRoom)
-				rooms.GET("/me", h.roomHandler.GetUserRooms)
+				rooms.GET("", readRooms, middleware.RestrictGuestRoom(""), h.roomHandler.GetRooms)
+				rooms.POST("", manageRooms, h.roomHandler.CreateRoom)
+				rooms.GET("/:id", readRooms, middleware.RestrictGuestRoom("id"), h.roomHandler.GetRoom)
+				rooms.POST("/join", manageRooms, h.roomHandler.JoinRoom)
+				rooms.DELETE("/:id/leave", manageRooms, h.roomHandler.LeaveRoom)
+				rooms.GET("/me", readRooms, h.roomHandler.GetUserRooms)
+
+				// 채팅방 설정, 보관, 삭제, 소유권 이전 (역할 확인은 서비스에서, 게스트 토큰에는 rooms:manage가 없음)
+				rooms.PATCH("/:id", manageRooms, h.roomHandler.UpdateRoom)
+				rooms.DELETE("/:id", manageRooms, h.roomHandler.DeleteRoom)
+				rooms.POST("/:id/archive", manageRooms, h.roomHandler.ArchiveRoom)
+				rooms.POST("/:id/unarchive", manageRooms, h.roomHandler.UnarchiveRoom)
+				rooms.POST("/:id/transfer", manageRooms, h.roomHandler.TransferOwnership)
+				h.registerShareLinkRoutes(rooms)
+
+				// 멤버 역할 변경 (채팅방 관리자 이상), 내보내기와 차단 (모더레이터 이상)
+				rooms.GET("/:id/members", readRooms, middleware.RestrictGuestRoom("id"), h.roomHandler.ListMembers)
+				members := rooms.Group("/:id/members", middleware.RequireUserToken())
+				{
+					members.PUT("/:userId/role", h.roomHandler.UpdateMemberRole)
+					members.POST("/:userId/kick", h.roomHandler.KickMember)
+				}
+				bans := rooms.Group("/:id/bans", middleware.RequireUserToken())
+				{
+					bans.GET("", h.roomHandler.ListBans)
+					bans.PUT("/:userId", h.roomHandler.BanMember)
+					bans.DELETE("/:userId", h.roomHandler.UnbanMember)
+				}
+
+				// 초대 코드 관리 (채팅방 모더레이터 이상)
+				invites := rooms.Group("/:id/invites", middleware.RequireUserToken())
+				{
+					invites.GET("", h.roomHandler.ListInvites)
+					invites.POST("", h.roomHandler.CreateInvite)
+					invites.DELETE("/:inviteId", h.roomHandler.RevokeInvite)
+				}
 			}
 
-			// 메시지 라우트
+			// 메시지 라우트 (게스트는 참여한 채팅방에만 쓸 수 있음)
 			messages := protected.Group("/messages")
 			{
-				messages.POST("", h.messageHandler.CreateMessage)
-				messages.GET("/room/:roomId", h.messageHandler.GetRoomMessages)
+				messages.POST("", middleware.RequireScope(models.ScopeMessagesWrite), h.messageHandler.CreateMessage)
+				messages.GET("/room/:roomId", readRooms, middleware.RestrictGuestRoom("roomId"), h.messageHandler.GetRoomMessages)
+				messages.DELETE("/:id", middleware.RequireScope(models.ScopeMessagesWrite), h.messageHandler.DeleteMessage)
 			}
 
-			// 웹소켓 라우트
+			// 웹소켓 라우트 (연결 티켓은 사용자 토큰이나 게스트 토큰으로만 발급)
+			protected.POST("/ws-ticket", middleware.RequireToken(), h.webSocketHandler.IssueTicket)
 		}
 		api.GET("/ws", h.webSocketHandler.HandleWebSocket)
 
@@ -87,9 +167,11 @@ func (h *Handler) GetProfile(c *gin.Context) {
 		ID       uint   `json:"id"`
 		Username string `json:"username"`
 		Email    string `json:"email"`
+		Role     string `json:"role"`
+		IsGuest  bool   `json:"isGuest"`
 	}
 
-	if err := h.db.Table("users").Select("id, username, email").Where("id = ?", userID).First(&user).Error; err != nil {
+	if err := h.db.Table("users").Select("id, username, email, role, is_guest").Where("id = ?", userID).First(&user).Error; err != nil {
 		appErr := errors.MapError(errors.ErrUserNotFound)
 		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
 		return
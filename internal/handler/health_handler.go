@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) registerHealthRoutes(r *gin.Engine) {
+	r.GET("/metrics", h.Metrics)
+	health := r.Group("/health")
+	{
+		health.GET("/live", h.Liveness)
+		health.GET("/ready", h.Readiness)
+	}
+}
+
+// Metrics는 Kafka 지표를 Prometheus 텍스트 형식으로 반환합니다
+func (h *Handler) Metrics(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; version=0.0.4")
+	c.Status(http.StatusOK)
+	h.kafka.Stats().WritePrometheus(c.Writer)
+}
+
+// Liveness는 프로세스가 요청을 처리할 수 있으면 항상 성공합니다
+func (h *Handler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness는 데이터베이스와 Kafka 브로커 상태를 확인합니다
+func (h *Handler) Readiness(c *gin.Context) {
+	checks := gin.H{}
+	ready := true
+
+	if sqlDB, err := h.db.DB(); err != nil || sqlDB.PingContext(c.Request.Context()) != nil {
+		checks["database"] = "unreachable"
+		ready = false
+	} else {
+		checks["database"] = "ok"
+	}
+
+	// 회로가 열려 있어도 로컬 전달은 가능하므로 임계 시간 전까지는 degraded로만 표시
+	status := "ok"
+	stats := h.kafka.Stats()
+	if err := h.kafka.CheckHealth(); err != nil {
+		checks["kafka"] = err.Error()
+		ready = false
+	} else if stats.Degraded() {
+		checks["kafka"] = "degraded"
+		status = "degraded"
+	} else {
+		checks["kafka"] = "ok"
+	}
+
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "degraded": stats.Degraded(), "checks": checks})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": status, "degraded": stats.Degraded(), "checks": checks})
+}
@@ -46,6 +46,7 @@ func (h *MessageHandler) CreateMessage(c *gin.Context) {
 
 // GetRoomMessages는 특정 채팅방의 메시지 목록을 반환합니다
 func (h *MessageHandler) GetRoomMessages(c *gin.Context) {
+	userID, _ := c.Get("userID")
 	roomId := c.Param("roomId")
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
@@ -65,11 +66,12 @@ func (h *MessageHandler) GetRoomMessages(c *gin.Context) {
 		Content   string    `json:"content"`
 		UserID    uint      `json:"userId"`
 		Username  string    `json:"username"`
+		IsGuest   bool      `json:"isGuest,omitempty"`
 		RoomID    uint      `json:"roomId"`
 		CreatedAt time.Time `json:"createdAt"`
 	}
 
-	err := h.messageService.GetMessagesByRoomId(roomId, limit, offset, &messages)
+	err := h.messageService.GetMessagesByRoomId(roomId, userID.(uint), limit, offset, &messages)
 	if err != nil {
 		appErr := errors.MapError(err)
 		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
@@ -78,3 +80,23 @@ func (h *MessageHandler) GetRoomMessages(c *gin.Context) {
 
 	c.JSON(http.StatusOK, messages)
 }
+
+// DeleteMessage는 메시지를 삭제합니다
+func (h *MessageHandler) DeleteMessage(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	messageID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	if err := h.messageService.DeleteMessage(uint(messageID), userID.(uint)); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Message deleted successfully"})
+}
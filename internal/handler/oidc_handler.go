@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"mult-working/internal/errors"
+	"mult-working/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) registerOIDCRoutes(r *gin.RouterGroup) {
+	oidc := r.Group("/auth/oidc")
+	{
+		oidc.GET("/providers", h.OIDCProviders)
+		oidc.GET("/:provider/login", h.OIDCLogin)
+		oidc.GET("/:provider/callback", h.OIDCCallback)
+	}
+}
+
+// OIDCProviders는 SSO 로그인에 사용할 수 있는 공급자 목록을 반환합니다
+func (h *Handler) OIDCProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"providers": h.oidcService.ProviderNames()})
+}
+
+// OIDCLogin은 공급자의 인증 페이지로 리다이렉트합니다
+func (h *Handler) OIDCLogin(c *gin.Context) {
+	url, err := h.oidcService.AuthCodeURL(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.Redirect(http.StatusFound, url)
+}
+
+// OIDCCallback은 공급자가 돌려준 인증 코드로 로그인하고 토큰 쌍을 반환합니다
+func (h *Handler) OIDCCallback(c *gin.Context) {
+	if idpErr := c.Query("error"); idpErr != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": idpErr, "error_description": c.Query("error_description")})
+		return
+	}
+
+	resp, err := h.oidcService.Callback(service.WithClientInfo(c.Request.Context(), clientInfo(c)), c.Param("provider"), c.Query("state"), c.Query("code"))
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -57,6 +57,8 @@ func (h *RoomHandler) GetRooms(c *gin.Context) {
 
 // GetRoom은 특정 채팅방 정보를 반환합니다
 func (h *RoomHandler) GetRoom(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
 	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		appErr := errors.MapError(errors.ErrInvalidRequest)
@@ -64,7 +66,7 @@ func (h *RoomHandler) GetRoom(c *gin.Context) {
 		return
 	}
 
-	room, err := h.roomService.GetRoom(uint(roomID))
+	room, err := h.roomService.GetRoom(uint(roomID), userID.(uint))
 	if err != nil {
 		appErr := errors.MapError(err)
 		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
@@ -74,7 +76,34 @@ func (h *RoomHandler) GetRoom(c *gin.Context) {
 	c.JSON(http.StatusOK, room)
 }
 
-// JoinRoom은 사용자를 채팅방에 참여시킵니다
+// ListMembers는 채팅방 멤버 목록을 역할, 참여 시각, 접속 상태와 함께 반환합니다.
+// cursor로 다음 페이지를, q로 사용자 이름 접두어 검색을 지정합니다
+func (h *RoomHandler) ListMembers(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	members, err := h.roomService.ListMembers(uint(roomID), userID.(uint), c.Query("q"), c.Query("cursor"), limit)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// JoinRoom은 사용자를 채팅방에 참여시킵니다. 공개 채팅방이 아니면 초대 코드가 필요합니다
 func (h *RoomHandler) JoinRoom(c *gin.Context) {
 	userID, _ := c.Get("userID")
 
@@ -85,7 +114,7 @@ func (h *RoomHandler) JoinRoom(c *gin.Context) {
 		return
 	}
 
-	if err := h.roomService.JoinRoom(req.RoomID, userID.(uint)); err != nil {
+	if err := h.roomService.JoinRoom(req.RoomID, userID.(uint), req.InviteCode); err != nil {
 		appErr := errors.MapError(err)
 		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
 		return
@@ -126,3 +155,341 @@ func (h *RoomHandler) GetUserRooms(c *gin.Context) {
 
 	c.JSON(http.StatusOK, rooms)
 }
+
+// CreateInvite는 채팅방 초대 코드를 만듭니다. 초대 코드는 이 응답에서만 볼 수 있습니다
+func (h *RoomHandler) CreateInvite(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	var req dto.CreateInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	invite, err := h.roomService.CreateInvite(uint(roomID), userID.(uint), req)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
+// ListInvites는 채팅방의 초대 코드 목록을 반환합니다
+func (h *RoomHandler) ListInvites(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	invites, err := h.roomService.ListInvites(uint(roomID), userID.(uint))
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, invites)
+}
+
+// RevokeInvite는 초대 코드를 폐기합니다
+func (h *RoomHandler) RevokeInvite(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+	inviteID, err := strconv.ParseUint(c.Param("inviteId"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	if err := h.roomService.RevokeInvite(uint(roomID), userID.(uint), uint(inviteID)); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invite revoked successfully"})
+}
+
+// UpdateMemberRole은 채팅방 멤버의 역할을 바꿉니다 (승격, 강등)
+func (h *RoomHandler) UpdateMemberRole(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+	targetID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	var req dto.UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	member, err := h.roomService.SetMemberRole(uint(roomID), userID.(uint), uint(targetID), req.Role)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// KickMember는 채팅방 멤버를 내보냅니다
+func (h *RoomHandler) KickMember(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+	targetID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	// 사유는 선택이므로 본문이 없어도 됨
+	var req dto.KickMemberRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			appErr := errors.MapError(err)
+			c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+			return
+		}
+	}
+
+	if err := h.roomService.KickMember(uint(roomID), userID.(uint), uint(targetID), req.Reason); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member kicked successfully"})
+}
+
+// BanMember는 사용자를 채팅방에서 차단합니다
+func (h *RoomHandler) BanMember(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+	targetID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	var req dto.BanMemberRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			appErr := errors.MapError(err)
+			c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+			return
+		}
+	}
+
+	ban, err := h.roomService.BanMember(uint(roomID), userID.(uint), uint(targetID), req)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, ban)
+}
+
+// UnbanMember는 채팅방 차단을 해제합니다
+func (h *RoomHandler) UnbanMember(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+	targetID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	if err := h.roomService.UnbanMember(uint(roomID), userID.(uint), uint(targetID)); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ban lifted successfully"})
+}
+
+// ListBans는 채팅방의 차단 목록을 반환합니다
+func (h *RoomHandler) ListBans(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	bans, err := h.roomService.ListBans(uint(roomID), userID.(uint))
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, bans)
+}
+
+// UpdateRoom은 채팅방 이름, 설명, 주제를 바꿉니다
+func (h *RoomHandler) UpdateRoom(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	var req dto.UpdateRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	room, err := h.roomService.UpdateRoom(uint(roomID), userID.(uint), req)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// ArchiveRoom은 채팅방을 보관해 읽기 전용으로 만듭니다
+func (h *RoomHandler) ArchiveRoom(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// UnarchiveRoom은 보관된 채팅방을 다시 쓸 수 있게 합니다
+func (h *RoomHandler) UnarchiveRoom(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+func (h *RoomHandler) setArchived(c *gin.Context, archived bool) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	var room *dto.RoomResponse
+	if archived {
+		room, err = h.roomService.ArchiveRoom(uint(roomID), userID.(uint))
+	} else {
+		room, err = h.roomService.UnarchiveRoom(uint(roomID), userID.(uint))
+	}
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// DeleteRoom은 채팅방을 삭제합니다 (소유자만)
+func (h *RoomHandler) DeleteRoom(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	if err := h.roomService.DeleteRoom(uint(roomID), userID.(uint)); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Room deleted successfully"})
+}
+
+// TransferOwnership은 채팅방 소유권을 다른 멤버에게 넘깁니다
+func (h *RoomHandler) TransferOwnership(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	var req dto.TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	if err := h.roomService.TransferOwnership(uint(roomID), userID.(uint), req.UserID); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ownership transferred successfully"})
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"mult-working/internal/errors"
+	"mult-working/internal/middleware"
+	"mult-working/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) registerSessionRoutes(r *gin.RouterGroup) {
+	sessions := r.Group("/sessions", middleware.RequireUserToken())
+	{
+		sessions.GET("", h.ListSessions)
+		sessions.DELETE("/:id", h.RevokeSession)
+	}
+}
+
+// ListSessions는 현재 사용자가 로그인한 세션(기기) 목록을 반환합니다
+func (h *Handler) ListSessions(c *gin.Context) {
+	claims := c.MustGet("tokenClaims").(*service.TokenClaims)
+
+	sessions, err := h.authService.ListSessions(claims.UserID, claims.SessionID)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession은 세션을 폐기합니다. 그 세션의 토큰은 더 이상 사용할 수 없고 웹소켓 연결도 끊깁니다
+func (h *Handler) RevokeSession(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	if err := h.authService.RevokeSession(userID.(uint), c.Param("id")); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
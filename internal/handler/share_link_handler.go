@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/middleware"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerShareLinkRoutes는 채팅방 관리자의 공유 링크 관리 라우트를 등록합니다
+func (h *Handler) registerShareLinkRoutes(rooms *gin.RouterGroup) {
+	links := rooms.Group("/:id/share-links", middleware.RequireUserToken())
+	{
+		links.GET("", h.ListShareLinks)
+		links.POST("", h.CreateShareLink)
+		links.DELETE("/:linkId", h.RevokeShareLink)
+	}
+}
+
+// registerGuestRoutes는 로그인 없이 공유 링크로 입장하는 공개 라우트를 등록합니다
+func (h *Handler) registerGuestRoutes(api *gin.RouterGroup) {
+	api.POST("/guest/join", h.JoinAsGuest)
+}
+
+// CreateShareLink는 채팅방의 게스트 공유 링크를 만듭니다. 링크 토큰은 이 응답에서만 볼 수 있습니다
+func (h *Handler) CreateShareLink(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	var req dto.CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	link, err := h.guestService.CreateShareLink(uint(roomID), userID.(uint), req)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+// ListShareLinks는 채팅방의 공유 링크 목록을 반환합니다
+func (h *Handler) ListShareLinks(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	links, err := h.guestService.ListShareLinks(uint(roomID), userID.(uint))
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// RevokeShareLink는 공유 링크를 폐기합니다
+func (h *Handler) RevokeShareLink(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+	linkID, err := strconv.ParseUint(c.Param("linkId"), 10, 32)
+	if err != nil {
+		appErr := errors.MapError(errors.ErrInvalidRequest)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	if err := h.guestService.RevokeShareLink(uint(roomID), userID.(uint), uint(linkID)); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Share link revoked successfully"})
+}
+
+// JoinAsGuest는 공유 링크로 게스트 계정을 만들고 그 채팅방에만 쓸 수 있는 토큰을 발급합니다
+func (h *Handler) JoinAsGuest(c *gin.Context) {
+	var req dto.GuestJoinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	resp, err := h.guestService.JoinAsGuest(req, clientInfo(c))
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
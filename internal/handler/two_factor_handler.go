@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) registerTwoFactorRoutes(r *gin.RouterGroup) {
+	twoFactor := r.Group("/2fa", middleware.RequireUserToken())
+	{
+		twoFactor.POST("/enroll", h.EnrollTwoFactor)
+		twoFactor.POST("/confirm", h.ConfirmTwoFactor)
+		twoFactor.POST("/disable", h.DisableTwoFactor)
+		twoFactor.POST("/recovery-codes", h.RegenerateRecoveryCodes)
+	}
+}
+
+// LoginTwoFactor는 로그인 챌린지 토큰과 2단계 코드를 확인하고 토큰 쌍을 반환합니다
+func (h *Handler) LoginTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authService.LoginTwoFactor(req, clientInfo(c))
+	if err != nil {
+		setRetryAfter(c, err)
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// EnrollTwoFactor는 새 TOTP 비밀 키와 인증 앱 등록 URI를 반환합니다
+func (h *Handler) EnrollTwoFactor(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	enrollment, err := h.authService.TwoFactor().Enroll(userID.(uint))
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// ConfirmTwoFactor는 인증 앱의 코드로 등록을 확인하고 복구 코드를 반환합니다. 복구 코드는 이때만 볼 수 있습니다
+func (h *Handler) ConfirmTwoFactor(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.authService.TwoFactor().Confirm(userID.(uint), req.Code)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+// DisableTwoFactor는 현재 코드를 확인한 뒤 2단계 인증을 끕니다
+func (h *Handler) DisableTwoFactor(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.TwoFactor().Disable(userID.(uint), req.Code); err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes는 기존 복구 코드를 폐기하고 새로 발급합니다
+func (h *Handler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.authService.TwoFactor().RegenerateRecoveryCodes(userID.(uint), req.Code)
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
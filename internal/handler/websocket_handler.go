@@ -2,13 +2,14 @@ package handler
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"mult-working/internal/config"
 	"mult-working/internal/dto"
+	"mult-working/internal/errors"
 	"mult-working/internal/service"
 	"mult-working/pkg/kafka"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -16,6 +17,14 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// Kafka 메시지 타입
+const (
+	kafkaMessageTypeMessage       = "message"
+	kafkaMessageTypeTokenRevoked  = "token_revoked"
+	kafkaMessageTypeMemberRemoved = "member_removed"
+	kafkaMessageTypeRoomDeleted   = "room_deleted"
+)
+
 // Kafka 메시지 구조체
 type KafkaMessage struct {
 	Type    string          `json:"type"`
@@ -23,20 +32,42 @@ type KafkaMessage struct {
 	Payload json.RawMessage `json:"payload"`
 }
 
+// PublishRevocation은 토큰 폐기를 모든 인스턴스에 알려 해당 토큰으로 인증된 소켓을 닫게 합니다
+func PublishRevocation(producer kafka.Producer, rev service.Revocation) error {
+	payload, err := json.Marshal(rev)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(KafkaMessage{
+		Type:    kafkaMessageTypeTokenRevoked,
+		Payload: payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	userKey := []byte(strconv.FormatUint(uint64(rev.UserID), 10))
+	return producer.ProduceWithKey("myapp-topic", userKey, data)
+}
+
 // WebSocketHandler는 웹소켓 관련 핸들러입니다
 type WebSocketHandler struct {
 	messageService *service.MessageService
 	authService    *service.AuthService
 	clients        map[uint]map[*websocket.Conn]bool
 	rooms          map[uint]map[*websocket.Conn]bool
+	connClaims     map[*websocket.Conn]*service.TokenClaims
+	presence       *service.PresenceStore
 	mutex          sync.Mutex
+	stopHeartbeat  chan struct{}
 	upgrader       websocket.Upgrader
 	kafkaProducer  kafka.Producer
 	kafkaConsumer  kafka.Consumer
 }
 
 // NewWebSocketHandler는 새로운 WebSocketHandler 인스턴스를 생성합니다
-func NewWebSocketHandler(messageService *service.MessageService, authService *service.AuthService, kafkaProducer kafka.Producer, kafkaConsumer kafka.Consumer) *WebSocketHandler {
+func NewWebSocketHandler(messageService *service.MessageService, authService *service.AuthService, presence *service.PresenceStore, kafkaProducer kafka.Producer, kafkaConsumer kafka.Consumer) *WebSocketHandler {
 	// 서버 인스턴스 ID 생성 (UUID 또는 호스트명+프로세스ID 등으로 생성)
 
 	handler := &WebSocketHandler{
@@ -44,6 +75,9 @@ func NewWebSocketHandler(messageService *service.MessageService, authService *se
 		authService:    authService,
 		clients:        make(map[uint]map[*websocket.Conn]bool),
 		rooms:          make(map[uint]map[*websocket.Conn]bool),
+		connClaims:     make(map[*websocket.Conn]*service.TokenClaims),
+		presence:       presence,
+		stopHeartbeat:  make(chan struct{}),
 		kafkaProducer:  kafkaProducer,
 		kafkaConsumer:  kafkaConsumer,
 		upgrader: websocket.Upgrader{
@@ -56,12 +90,50 @@ func NewWebSocketHandler(messageService *service.MessageService, authService *se
 		},
 	}
 
-	// Kafka 컨슈머 설정 및 메시지 리스넝을 시작
+	// Kafka 컨슈머에 메시지 핸들러 등록 (소비 시작은 애플리케이션 라이프사이클에서 수행)
 	handler.setupKafkaConsumer()
 
+	// 토큰 폐기 시 로컬 소켓을 닫고 다른 인스턴스에도 전파
+	authService.OnRevoke(handler.handleRevocation)
+
+	// 접속 상태가 만료되지 않도록 주기적으로 갱신
+	go handler.runPresenceHeartbeat()
+
 	return handler
 }
 
+// runPresenceHeartbeat는 이 인스턴스에 연결된 사용자의 접속 상태를 주기적으로 갱신합니다
+func (h *WebSocketHandler) runPresenceHeartbeat() {
+	ticker := time.NewTicker(service.PresenceHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.mutex.Lock()
+			userIDs := make([]uint, 0, len(h.clients))
+			for userID, conns := range h.clients {
+				if len(conns) > 0 {
+					userIDs = append(userIDs, userID)
+				}
+			}
+			h.mutex.Unlock()
+
+			if err := h.presence.Heartbeat(userIDs); err != nil {
+				log.Printf("Failed to refresh presence: %v", err)
+			}
+		case <-h.stopHeartbeat:
+			return
+		}
+	}
+}
+
+// Close는 접속 상태 갱신을 멈추고 이 인스턴스의 접속 상태를 지웁니다
+func (h *WebSocketHandler) Close() error {
+	close(h.stopHeartbeat)
+	return h.presence.Clear()
+}
+
 func (h *WebSocketHandler) setupKafkaConsumer() {
 
 	// Kafka 컨슈머 생성
@@ -75,6 +147,32 @@ func (h *WebSocketHandler) setupKafkaConsumer() {
 			return err
 		}
 
+		if kafkaMsg.Type == kafkaMessageTypeTokenRevoked {
+			var rev service.Revocation
+			if err := json.Unmarshal(kafkaMsg.Payload, &rev); err != nil {
+				log.Printf("Error parsing revocation message: %v", err)
+				return err
+			}
+			h.authService.InvalidateRevocations()
+			h.closeRevokedConnections(rev)
+			return nil
+		}
+
+		if kafkaMsg.Type == kafkaMessageTypeMemberRemoved {
+			var removal service.RoomRemoval
+			if err := json.Unmarshal(kafkaMsg.Payload, &removal); err != nil {
+				log.Printf("Error parsing member removal message: %v", err)
+				return err
+			}
+			h.unsubscribeRemovedMember(removal)
+			return nil
+		}
+
+		if kafkaMsg.Type == kafkaMessageTypeRoomDeleted {
+			h.closeRoom(kafkaMsg.RoomID)
+			return nil
+		}
+
 		// 발신 서버 ID가 현재 서버와 같으면 스킵 (이미 로컬에서 처리됨)
 		var metadata struct {
 			Payload json.RawMessage `json:"payload"`
@@ -83,10 +181,8 @@ func (h *WebSocketHandler) setupKafkaConsumer() {
 			ServerID string `json:"serverId"`
 		}
 		if err := json.Unmarshal(kafkaMsg.Payload, &metadata); err == nil {
-			fmt.Println("metadata", string(kafkaMsg.Payload))
 			if metadata.Payload != nil {
 				if err := json.Unmarshal(metadata.Payload, &Message); err == nil {
-					fmt.Println("Message", Message.ServerID)
 					if Message.ServerID == config.ServerInstanceID {
 						return nil
 					}
@@ -98,47 +194,44 @@ func (h *WebSocketHandler) setupKafkaConsumer() {
 		h.localBroadcastToRoom(kafkaMsg.RoomID, kafkaMsg.Payload)
 		return nil
 	})
-
-	// 컨슈머 시작
-	consumer.Start()
 }
 
-// HandleWebSocket은 웹소켓 연결을 처리합니다
-func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
-	// 쿼리 파라미터에서 토큰 가져오기
-	token := c.Query("token")
-	log.Printf("WebSocket connection attempt with token: %v", token != "")
+// IssueTicket은 웹소켓 연결에 사용할 일회용 티켓을 발급합니다
+func (h *WebSocketHandler) IssueTicket(c *gin.Context) {
+	claims := c.MustGet("tokenClaims").(*service.TokenClaims)
 
-	if token != "" {
-		// 토큰이 쿼리 파라미터로 전달된 경우, 헤더에 설정
-		c.Request.Header.Set("Authorization", "Bearer "+token)
+	ticket, err := h.authService.IssueWSTicket(claims, c.ClientIP())
+	if err != nil {
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+		return
+	}
 
-		// 인증 처리를 위해 AuthService 호출
-		userID, err := h.authService.VerifyToken(token)
-		if err != nil {
-			log.Printf("Token verification failed: %v", err)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-			return
-		}
+	c.JSON(http.StatusOK, ticket)
+}
 
-		// 유효한 토큰인 경우 userID 설정
-		c.Set("userID", userID)
-		log.Printf("Token verified for user ID: %v", userID)
-	} else {
-		log.Printf("No token provided in WebSocket connection")
-	}
+// wsAuthTimeout은 티켓 없이 연결한 클라이언트가 첫 프레임으로 인증해야 하는 시간입니다
+const wsAuthTimeout = 10 * time.Second
 
-	// 기존 코드와 동일하게 userID 가져오기
-	userID, exists := c.Get("userID")
-	if !exists {
-		log.Printf("No userID in context, unauthorized")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+// HandleWebSocket은 웹소켓 연결을 처리합니다.
+// 액세스 토큰이 URL에 남지 않도록 ?ticket=<웹소켓 티켓>으로 연결하거나,
+// 연결 직후 첫 프레임으로 {"type":"auth","payload":{"ticket":"..."}}(또는 "token")을 보내야 합니다.
+// 이전 클라이언트의 ?token=<액세스 토큰>은 auth.allow_ws_query_token이 켜져 있을 때만 받습니다 (폐기 예정)
+func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
+	var claims *service.TokenClaims
+	var err error
+	if ticket := c.Query("ticket"); ticket != "" {
+		claims, err = h.authService.RedeemWSTicket(ticket, c.ClientIP())
+	} else if token := c.Query("token"); token != "" {
+		claims, err = h.authService.VerifyWSQueryToken(token)
+	}
+	if err != nil {
+		log.Printf("WebSocket query authentication rejected: %v", err)
+		appErr := errors.MapError(err)
+		c.JSON(appErr.StatusCode, gin.H{"error": appErr.Message})
 		return
 	}
 
-	userIDUint := userID.(uint)
-	log.Printf("Upgrading WebSocket connection for user ID: %v", userIDUint)
-
 	// 웹소켓 연결 업그레이드
 	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -146,7 +239,19 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 		return
 	}
 
-	log.Printf("WebSocket connection upgraded successfully for user ID: %v", userIDUint)
+	if claims == nil {
+		claims, err = h.authenticateFirstFrame(conn, c.ClientIP())
+		if err != nil {
+			log.Printf("WebSocket authentication failed: %v", err)
+			msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "unauthorized")
+			conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+			conn.Close()
+			return
+		}
+	}
+
+	userIDUint := claims.UserID
+	log.Printf("WebSocket connection authenticated for user ID: %v", userIDUint)
 
 	// 클라이언트 등록
 	h.mutex.Lock()
@@ -154,19 +259,40 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 		h.clients[userIDUint] = make(map[*websocket.Conn]bool)
 	}
 	h.clients[userIDUint][conn] = true
+	h.connClaims[conn] = claims
+	firstConn := len(h.clients[userIDUint]) == 1
 	h.mutex.Unlock()
 
+	// 이 인스턴스의 첫 소켓이면 온라인으로 표시
+	if firstConn {
+		if err := h.presence.Connected(userIDUint); err != nil {
+			log.Printf("Failed to record presence for user %d: %v", userIDUint, err)
+		}
+	}
+
 	// 클라이언트 연결 종료 시 정리
 	defer func() {
 		h.mutex.Lock()
 		delete(h.clients[userIDUint], conn)
+		delete(h.connClaims, conn)
 		// 모든 방에서 클라이언트 제거
 		for roomID, clients := range h.rooms {
 			if _, ok := clients[conn]; ok {
 				delete(h.rooms[roomID], conn)
 			}
 		}
+		lastConn := len(h.clients[userIDUint]) == 0
+		if lastConn {
+			delete(h.clients, userIDUint)
+		}
 		h.mutex.Unlock()
+
+		// 이 인스턴스의 마지막 소켓이면 오프라인으로 표시
+		if lastConn {
+			if err := h.presence.Disconnected(userIDUint); err != nil {
+				log.Printf("Failed to clear presence for user %d: %v", userIDUint, err)
+			}
+		}
 	}()
 
 	// 메시지 처리
@@ -184,7 +310,6 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 			Type    string          `json:"type"`
 			Payload json.RawMessage `json:"payload"`
 		}
-		fmt.Println(string(message))
 		if err := json.Unmarshal(message, &msg); err != nil {
 			log.Printf("Failed to parse message: %v", err)
 			continue
@@ -200,6 +325,16 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 				log.Printf("Failed to parse join_room payload: %v", err)
 				continue
 			}
+			// 게스트는 공유 링크의 채팅방에만 참여할 수 있음
+			if claims.IsGuest() && payload.RoomID != claims.GuestRoomID {
+				log.Printf("Guest %d tried to join room %d", userIDUint, payload.RoomID)
+				continue
+			}
+			// 공개 채팅방이 아니면 멤버만 구독할 수 있음
+			if err := h.messageService.CanSubscribe(payload.RoomID, userIDUint); err != nil {
+				log.Printf("User %d cannot join room %d: %v", userIDUint, payload.RoomID, err)
+				continue
+			}
 			h.handleJoinRoom(conn, payload.RoomID, userIDUint)
 
 		case "leave_room":
@@ -226,6 +361,45 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 	}
 }
 
+// authenticateFirstFrame은 티켓 없이 연결한 클라이언트의 첫 프레임(auth 메시지)으로 인증합니다
+func (h *WebSocketHandler) authenticateFirstFrame(conn *websocket.Conn, ip string) (*service.TokenClaims, error) {
+	conn.SetReadDeadline(time.Now().Add(wsAuthTimeout))
+	_, message, err := conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	var msg struct {
+		Type    string `json:"type"`
+		Payload struct {
+			Ticket string `json:"ticket"`
+			Token  string `json:"token"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(message, &msg); err != nil || msg.Type != "auth" {
+		return nil, errors.ErrInvalidToken
+	}
+
+	var claims *service.TokenClaims
+	switch {
+	case msg.Payload.Ticket != "":
+		claims, err = h.authService.RedeemWSTicket(msg.Payload.Ticket, ip)
+	case msg.Payload.Token != "":
+		claims, err = h.authService.VerifyTokenClaims(msg.Payload.Token)
+	default:
+		err = errors.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.WriteJSON(map[string]interface{}{"type": "auth_ok"}); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
 // handleJoinRoom은 채팅방 참여 요청을 처리합니다
 func (h *WebSocketHandler) handleJoinRoom(conn *websocket.Conn, roomID, userID uint) {
 	// 채팅방에 참여
@@ -239,10 +413,11 @@ func (h *WebSocketHandler) handleJoinRoom(conn *websocket.Conn, roomID, userID u
 	// 참여 메시지 브로드캐스트
 	var user struct {
 		Username string
+		IsGuest  bool
 	}
 
 	// DB 필드 접근 문제 해결
-	if err := h.messageService.GetDB().Table("users").Select("username").Where("id = ?", userID).First(&user).Error; err != nil {
+	if err := h.messageService.GetDB().Table("users").Select("username, is_guest").Where("id = ?", userID).First(&user).Error; err != nil {
 		log.Printf("Failed to get username: %v", err)
 		return
 	}
@@ -252,6 +427,7 @@ func (h *WebSocketHandler) handleJoinRoom(conn *websocket.Conn, roomID, userID u
 		"payload": map[string]interface{}{
 			"userId":   userID,
 			"username": user.Username,
+			"isGuest":  user.IsGuest,
 			"roomId":   roomID,
 			"time":     time.Now(),
 		},
@@ -317,7 +493,7 @@ func (h *WebSocketHandler) broadcastToRoom(roomID uint, message interface{}) {
 
 	// Kafka 메시지 구조체 생성
 	kafkaMsg := KafkaMessage{
-		Type:    "message",
+		Type:    kafkaMessageTypeMessage,
 		RoomID:  roomID,
 		Payload: data,
 	}
@@ -329,9 +505,11 @@ func (h *WebSocketHandler) broadcastToRoom(roomID uint, message interface{}) {
 		return
 	}
 
-	if err := h.kafkaProducer.Produce("myapp-topic", kafkaData); err != nil {
+	// 채팅방 ID를 키로 사용해 같은 방의 메시지 순서를 보장
+	roomKey := []byte(strconv.FormatUint(uint64(roomID), 10))
+	if err := h.kafkaProducer.ProduceWithKey("myapp-topic", roomKey, kafkaData); err != nil {
+		// 브로커 발행 실패와 관계없이 같은 인스턴스의 사용자에게는 전달
 		log.Printf("Failed to produce Kafka message: %v", err)
-		return
 	}
 
 	// 로컬 클라이언트에게도 바로 메시지 전송
@@ -356,3 +534,155 @@ func (h *WebSocketHandler) localBroadcastToRoom(roomID uint, message json.RawMes
 		log.Printf("No clients in room %d", roomID)
 	}
 }
+
+// handleRevocation은 로컬 소켓을 닫고 다른 인스턴스에 폐기를 전파합니다
+func (h *WebSocketHandler) handleRevocation(rev service.Revocation) {
+	h.closeRevokedConnections(rev)
+
+	if err := PublishRevocation(h.kafkaProducer, rev); err != nil {
+		log.Printf("Failed to publish revocation: %v", err)
+	}
+}
+
+// revokes는 폐기 정보가 연결을 인증한 토큰에 해당하는지 확인합니다
+func revokes(rev service.Revocation, claims *service.TokenClaims) bool {
+	switch {
+	case rev.TokenID != "":
+		return claims != nil && claims.TokenID == rev.TokenID
+	case rev.SessionID != "":
+		return claims != nil && claims.SessionID == rev.SessionID
+	default:
+		return true
+	}
+}
+
+// closeRevokedConnections는 폐기된 토큰이나 세션(또는 사용자의 모든 토큰)으로 인증된 로컬 소켓을 닫습니다
+func (h *WebSocketHandler) closeRevokedConnections(rev service.Revocation) {
+	h.mutex.Lock()
+	var conns []*websocket.Conn
+	for conn := range h.clients[rev.UserID] {
+		if revokes(rev, h.connClaims[conn]) {
+			conns = append(conns, conn)
+		}
+	}
+	h.mutex.Unlock()
+
+	for _, conn := range conns {
+		// 읽기 루프가 종료되면서 연결 정리가 수행됨
+		msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "token revoked")
+		conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+		conn.Close()
+	}
+
+	if len(conns) > 0 {
+		log.Printf("Closed %d connections for revoked token of user %d", len(conns), rev.UserID)
+	}
+}
+
+// handleMemberRemoval은 내보내거나 차단한 사용자의 로컬 소켓 구독을 해제하고,
+// 다른 인스턴스에 전파한 뒤 채팅방에 알립니다
+func (h *WebSocketHandler) handleMemberRemoval(removal service.RoomRemoval) {
+	h.unsubscribeRemovedMember(removal)
+
+	payload, err := json.Marshal(removal)
+	if err != nil {
+		log.Printf("Failed to marshal member removal: %v", err)
+		return
+	}
+	data, err := json.Marshal(KafkaMessage{
+		Type:    kafkaMessageTypeMemberRemoved,
+		RoomID:  removal.RoomID,
+		Payload: payload,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal member removal: %v", err)
+		return
+	}
+
+	// 채팅방 메시지와 같은 키를 써서, 알림보다 구독 해제가 먼저 처리되도록 함
+	roomKey := []byte(strconv.FormatUint(uint64(removal.RoomID), 10))
+	if err := h.kafkaProducer.ProduceWithKey("myapp-topic", roomKey, data); err != nil {
+		log.Printf("Failed to publish member removal: %v", err)
+	}
+
+	h.broadcastToRoom(removal.RoomID, map[string]interface{}{
+		"type": "user_removed",
+		"payload": map[string]interface{}{
+			"userId": removal.UserID,
+			"roomId": removal.RoomID,
+			"banned": removal.Banned,
+			"time":   time.Now(),
+		},
+	})
+}
+
+// unsubscribeRemovedMember는 내보내진 사용자의 로컬 소켓을 채팅방 구독에서 빼고, 그 소켓에 사유를 알립니다
+func (h *WebSocketHandler) unsubscribeRemovedMember(removal service.RoomRemoval) {
+	notice, err := json.Marshal(map[string]interface{}{
+		"type":    "removed_from_room",
+		"payload": removal,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal removal notice: %v", err)
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	removed := 0
+	for conn := range h.clients[removal.UserID] {
+		if _, ok := h.rooms[removal.RoomID][conn]; !ok {
+			continue
+		}
+		delete(h.rooms[removal.RoomID], conn)
+		removed++
+		if err := conn.WriteMessage(websocket.TextMessage, notice); err != nil {
+			log.Printf("Failed to send removal notice: %v", err)
+		}
+	}
+
+	if removed > 0 {
+		log.Printf("Unsubscribed %d connections of user %d from room %d", removed, removal.UserID, removal.RoomID)
+	}
+}
+
+// handleRoomUpdate는 채팅방 설정 변경이나 삭제를 채팅방 소켓에 알립니다.
+// 삭제된 채팅방은 알린 뒤 모든 인스턴스에서 구독을 정리합니다
+func (h *WebSocketHandler) handleRoomUpdate(update service.RoomUpdate) {
+	msgType := "room_updated"
+	if update.Deleted {
+		msgType = "room_deleted"
+	}
+	h.broadcastToRoom(update.Room.ID, map[string]interface{}{
+		"type":    msgType,
+		"payload": update.Room,
+	})
+
+	if !update.Deleted {
+		return
+	}
+	h.closeRoom(update.Room.ID)
+
+	data, err := json.Marshal(KafkaMessage{
+		Type:   kafkaMessageTypeRoomDeleted,
+		RoomID: update.Room.ID,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal room deletion: %v", err)
+		return
+	}
+
+	// 알림과 같은 키를 써서 알림이 전달된 뒤에 구독이 정리되도록 함
+	roomKey := []byte(strconv.FormatUint(uint64(update.Room.ID), 10))
+	if err := h.kafkaProducer.ProduceWithKey("myapp-topic", roomKey, data); err != nil {
+		log.Printf("Failed to publish room deletion: %v", err)
+	}
+}
+
+// closeRoom은 삭제된 채팅방의 로컬 구독을 모두 정리합니다
+func (h *WebSocketHandler) closeRoom(roomID uint) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.rooms, roomID)
+}
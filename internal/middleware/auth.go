@@ -1,12 +1,18 @@
 package middleware
 
 import (
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"mult-working/internal/errors"
+	"mult-working/internal/models"
 	"mult-working/internal/service"
 )
 
-// AuthMiddleware는 인증이 필요한 라우트를 보호하는 미들웨어입니다
+// AuthMiddleware는 인증이 필요한 라우트를 보호하는 미들웨어입니다.
+// "Authorization: Bearer <토큰>"은 사용자 토큰으로, "Authorization: ApiKey <키>"는 봇의 API 키로 인증합니다
 func JWTAuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := c.GetHeader("Authorization")
@@ -16,6 +22,21 @@ func JWTAuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
 			return
 		}
 
+		// API 키 인증: 키의 권한 범위는 RequireScope로 라우트마다 확인
+		if key, ok := strings.CutPrefix(token, "ApiKey "); ok {
+			apiKey, err := authService.APIKeys().Authenticate(key)
+			if err != nil {
+				appErr := errors.MapError(err)
+				c.AbortWithStatusJSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+				return
+			}
+
+			c.Set("userID", apiKey.UserID)
+			c.Set("apiKey", apiKey)
+			c.Next()
+			return
+		}
+
 		// Bearer 접두사 제거
 		if len(token) > 7 && token[:7] == "Bearer " {
 			token = token[7:]
@@ -25,15 +46,108 @@ func JWTAuthMiddleware(authService *service.AuthService) gin.HandlerFunc {
 			return
 		}
 		// 토큰 검증
-		userID, err := authService.VerifyToken(token)
+		claims, err := authService.VerifyTokenClaims(token)
+		if err != nil {
+			appErr := errors.MapError(err)
+			c.AbortWithStatusJSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+			return
+		}
+
+		// 사용자 ID와 토큰 클레임을 컨텍스트에 저장
+		c.Set("userID", claims.UserID)
+		c.Set("tokenClaims", claims)
+		c.Next()
+	}
+}
+
+// RequireScope는 API 키나 게스트 토큰으로 인증된 요청에 지정된 권한 범위가 있는지 확인합니다.
+// 일반 사용자 토큰으로 인증된 요청은 그대로 통과합니다
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed := true
+		if value, ok := c.Get("apiKey"); ok {
+			allowed = value.(*models.APIKey).HasScope(scope)
+		} else if claims, ok := tokenClaims(c); ok && claims.IsGuest() {
+			allowed = slices.Contains(claims.Scopes, scope)
+		}
+		if !allowed {
+			appErr := errors.MapError(errors.ErrInsufficientScope)
+			c.AbortWithStatusJSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireToken은 API 키로 인증된 요청을 거부합니다 (게스트 토큰은 허용)
+func RequireToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := tokenClaims(c); !ok {
+			appErr := errors.MapError(errors.ErrPermissionDenied)
+			c.AbortWithStatusJSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireUserToken은 API 키와 게스트 토큰으로 인증된 요청을 거부합니다 (계정 보안 설정, 봇 관리 등 가입한 사람만 할 수 있는 작업)
+func RequireUserToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if claims, ok := tokenClaims(c); !ok || claims.IsGuest() {
+			appErr := errors.MapError(errors.ErrPermissionDenied)
+			c.AbortWithStatusJSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RestrictGuestRoom은 게스트 토큰이 param 경로 파라미터의 채팅방에만 접근하도록 합니다.
+// param이 비어 있으면 게스트를 거부합니다 (채팅방 목록처럼 특정 방이 아닌 라우트)
+func RestrictGuestRoom(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if claims, ok := tokenClaims(c); ok && claims.IsGuest() {
+			var roomID uint64
+			if param != "" {
+				roomID, _ = strconv.ParseUint(c.Param(param), 10, 32)
+			}
+			if uint(roomID) != claims.GuestRoomID {
+				appErr := errors.MapError(errors.ErrPermissionDenied)
+				c.AbortWithStatusJSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// RequireRole은 JWTAuthMiddleware 뒤에서 사용자의 시스템 역할이 role 이상인지 확인합니다
+func RequireRole(authService *service.AuthService, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+
+		current, err := authService.UserRole(userID.(uint))
 		if err != nil {
 			appErr := errors.MapError(err)
 			c.AbortWithStatusJSON(appErr.StatusCode, gin.H{"error": appErr.Message})
 			return
 		}
+		if !models.RoleAtLeast(current, role) {
+			appErr := errors.MapError(errors.ErrPermissionDenied)
+			c.AbortWithStatusJSON(appErr.StatusCode, gin.H{"error": appErr.Message})
+			return
+		}
 
-		// 사용자 ID를 컨텍스트에 저장
-		c.Set("userID", userID)
+		c.Set("userRole", current)
 		c.Next()
 	}
 }
+
+func tokenClaims(c *gin.Context) (*service.TokenClaims, bool) {
+	value, ok := c.Get("tokenClaims")
+	if !ok {
+		return nil, false
+	}
+	return value.(*service.TokenClaims), true
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// 일회용 토큰 용도
+const (
+	ActionVerifyEmail   = "verify_email"
+	ActionResetPassword = "reset_password"
+)
+
+// ActionToken은 메일로 보낸 일회용 토큰(이메일 인증, 비밀번호 재설정)입니다.
+// 토큰 원문은 메일에만 담기고, 이 레코드에는 해시만 저장합니다
+type ActionToken struct {
+	// ID는 토큰 원문의 SHA-256 해시입니다
+	ID      string `gorm:"primaryKey;size:64" json:"id"`
+	UserID  uint   `gorm:"not null;index" json:"userId"`
+	Purpose string `gorm:"size:32;not null" json:"purpose"`
+	// Email은 발급 당시 주소입니다. 그 뒤 주소가 바뀌었으면 새 주소는 인증하지 않습니다
+	Email     string     `gorm:"size:255" json:"email"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expiresAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
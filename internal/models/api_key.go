@@ -0,0 +1,46 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// API 키 권한 범위
+const (
+	ScopeRoomsRead     = "rooms:read"
+	ScopeMessagesWrite = "messages:write"
+	ScopeRoomsManage   = "rooms:manage"
+)
+
+// APIKeyScopes는 API 키에 부여할 수 있는 모든 권한 범위입니다
+var APIKeyScopes = []string{ScopeRoomsRead, ScopeMessagesWrite, ScopeRoomsManage}
+
+// APIKey는 봇 사용자가 비밀번호 대신 사용하는 키입니다. 키 원문은 저장하지 않고 해시만 저장합니다
+type APIKey struct {
+	ID     uint   `gorm:"primarykey" json:"id"`
+	UserID uint   `gorm:"not null;index" json:"userId"`
+	Name   string `gorm:"size:100;not null" json:"name"`
+	// Prefix는 목록에서 키를 구별하기 위한 앞부분입니다
+	Prefix  string `gorm:"size:16;not null" json:"prefix"`
+	KeyHash string `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	// Scopes는 쉼표로 구분된 권한 범위입니다
+	Scopes     string     `gorm:"size:255;not null" json:"-"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// ScopeList는 키의 권한 범위 목록을 반환합니다
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return nil
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+// HasScope는 키에 지정된 권한 범위가 있는지 확인합니다
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.ScopeList(), scope)
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// 로그인 시도 결과
+const (
+	LoginSucceeded          = "success"
+	LoginInvalidCredentials = "invalid_credentials"
+	LoginInvalidTwoFactor   = "invalid_two_factor"
+	LoginTwoFactorRequired  = "two_factor_required"
+	LoginEmailNotVerified   = "email_not_verified"
+	LoginThrottled          = "throttled"
+)
+
+// LoginAttempt는 감사용 로그인 시도 기록입니다. 시도 횟수 제한에도 사용됩니다
+type LoginAttempt struct {
+	ID uint `gorm:"primarykey" json:"id"`
+	// AccountKey는 시도 횟수를 세는 계정 키입니다 (존재하는 사용자는 "user:<id>", 없는 이름은 "name:<이름>")
+	AccountKey string `gorm:"size:320;not null;index:idx_login_attempts_account" json:"accountKey"`
+	// Identifier는 입력된 사용자 이름 또는 이메일입니다
+	Identifier string    `gorm:"size:320" json:"identifier"`
+	UserID     *uint     `gorm:"index" json:"userId"`
+	IP         string    `gorm:"size:64;index:idx_login_attempts_ip" json:"ip"`
+	UserAgent  string    `gorm:"size:512" json:"userAgent"`
+	Success    bool      `gorm:"not null" json:"success"`
+	Result     string    `gorm:"size:32;not null" json:"result"`
+	CreatedAt  time.Time `gorm:"index:idx_login_attempts_account;index:idx_login_attempts_ip" json:"createdAt"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// Presence는 서버 인스턴스별로 웹소켓에 연결된 사용자입니다.
+// 인스턴스가 주기적으로 LastSeenAt을 갱신하므로, 갱신이 끊긴 행(비정상 종료한 인스턴스)은 오프라인으로 봅니다
+type Presence struct {
+	UserID     uint      `gorm:"primaryKey" json:"userId"`
+	InstanceID string    `gorm:"primaryKey;size:64" json:"instanceId"`
+	LastSeenAt time.Time `gorm:"not null;index" json:"lastSeenAt"`
+}
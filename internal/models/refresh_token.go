@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// RefreshToken은 해시된 리프레시 토큰 모델입니다.
+// 한 번의 로그인에서 회전(rotate)되며 발급된 토큰들은 같은 FamilyID를 공유합니다
+type RefreshToken struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"userId"`
+	FamilyID  string     `gorm:"size:64;not null;index" json:"familyId"`
+	TokenHash string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expiresAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
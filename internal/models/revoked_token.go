@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// RevokedToken은 만료 전에 폐기된 액세스 토큰 모델입니다. 토큰이 만료되면 삭제해도 됩니다
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey;size:64" json:"jti"`
+	UserID    uint      `gorm:"not null;index" json:"userId"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expiresAt"`
+	RevokedAt time.Time `gorm:"not null" json:"revokedAt"`
+}
@@ -6,25 +6,70 @@ import (
 	"gorm.io/gorm"
 )
 
+// 채팅방 공개 범위
+const (
+	// RoomPublic 채팅방은 목록에 보이고 누구나 참여할 수 있습니다
+	RoomPublic = "public"
+	// RoomInviteOnly 채팅방은 목록에 보이지만 초대가 있어야 참여할 수 있습니다
+	RoomInviteOnly = "invite_only"
+	// RoomPrivate 채팅방은 멤버에게만 보이고 초대가 있어야 참여할 수 있습니다
+	RoomPrivate = "private"
+)
+
 // Room은 채팅방 모델입니다
 type Room struct {
-	ID          uint           `gorm:"primarykey" json:"id"`
-	Name        string         `gorm:"size:100;not null" json:"name"`
-	Description string         `gorm:"size:500" json:"description"`
-	CreatedBy   uint           `gorm:"not null" json:"createdBy"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"-"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
-	Messages    []Message      `gorm:"foreignKey:RoomID" json:"-"`
-	Users       []*User        `gorm:"many2many:room_users;" json:"-"`
+	ID          uint   `gorm:"primarykey" json:"id"`
+	Name        string `gorm:"size:100;not null" json:"name"`
+	Description string `gorm:"size:500" json:"description"`
+	Visibility  string `gorm:"size:20;not null;default:public;index" json:"visibility"`
+	Topic       string `gorm:"size:250" json:"topic"`
+	// ArchivedAt이 있으면 보관된 채팅방입니다. 보관된 채팅방은 읽기만 할 수 있습니다
+	ArchivedAt *time.Time     `json:"archivedAt"`
+	CreatedBy  uint           `gorm:"not null" json:"createdBy"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"-"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	Messages   []Message      `gorm:"foreignKey:RoomID" json:"-"`
+	Users      []*User        `gorm:"many2many:room_users;" json:"-"`
+}
+
+// 채팅방 역할. 위 역할은 아래 역할의 권한을 모두 가집니다
+const (
+	RoomRoleOwner     = "owner"
+	RoomRoleAdmin     = "admin"
+	RoomRoleModerator = "moderator"
+	RoomRoleMember    = "member"
+	// RoomRoleReadOnly 멤버는 메시지를 읽을 수만 있습니다 (읽기 전용 공유 링크로 들어온 게스트 등)
+	RoomRoleReadOnly = "read_only"
+)
+
+var roomRoleRanks = map[string]int{
+	RoomRoleReadOnly:  0,
+	RoomRoleMember:    1,
+	RoomRoleModerator: 2,
+	RoomRoleAdmin:     3,
+	RoomRoleOwner:     4,
+}
+
+// ValidRoomRole은 정의된 채팅방 역할인지 확인합니다
+func ValidRoomRole(role string) bool {
+	_, ok := roomRoleRanks[role]
+	return ok
+}
+
+// RoomRoleAtLeast는 role이 required 이상의 채팅방 역할인지 확인합니다
+func RoomRoleAtLeast(role, required string) bool {
+	rank, ok := roomRoleRanks[role]
+	return ok && rank >= roomRoleRanks[required]
 }
 
 // RoomUser는 채팅방과 사용자의 다대다 관계를 나타내는 모델입니다
 type RoomUser struct {
-	RoomID    uint      `gorm:"primaryKey" json:"roomId"`
-	UserID    uint      `gorm:"primaryKey" json:"userId"`
-	JoinedAt  time.Time `json:"joinedAt"`
-	IsAdmin   bool      `gorm:"default:false" json:"isAdmin"`
+	RoomID   uint      `gorm:"primaryKey" json:"roomId"`
+	UserID   uint      `gorm:"primaryKey" json:"userId"`
+	JoinedAt time.Time `json:"joinedAt"`
+	// Role은 채팅방 안에서의 역할입니다 (owner, admin, moderator, member, read_only)
+	Role      string    `gorm:"size:16;not null;default:member" json:"role"`
 	CreatedAt time.Time `json:"-"`
 	UpdatedAt time.Time `json:"-"`
 }
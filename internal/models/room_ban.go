@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// RoomBan은 채팅방에서 차단된 사용자입니다. 차단된 사용자는 초대가 있어도 다시 참여할 수 없습니다.
+// ExpiresAt이 없으면 해제할 때까지 계속 차단됩니다
+type RoomBan struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	RoomID    uint       `gorm:"not null;uniqueIndex:idx_room_bans_room_user" json:"roomId"`
+	UserID    uint       `gorm:"not null;uniqueIndex:idx_room_bans_room_user" json:"userId"`
+	BannedBy  uint       `gorm:"not null" json:"bannedBy"`
+	Reason    string     `gorm:"size:500" json:"reason"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// RoomInvite는 초대가 필요한 채팅방에 참여할 수 있는 초대 코드입니다.
+// InviteeID가 있으면 그 사용자만 쓸 수 있는 개인 초대이고, 없으면 코드를 아는 누구나 쓸 수 있습니다.
+// 초대 코드 원문은 저장하지 않고 해시만 저장합니다
+type RoomInvite struct {
+	ID        uint   `gorm:"primarykey" json:"id"`
+	RoomID    uint   `gorm:"not null;index" json:"roomId"`
+	CreatedBy uint   `gorm:"not null" json:"createdBy"`
+	CodeHash  string `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	InviteeID *uint  `gorm:"index" json:"inviteeId"`
+	// MaxUses가 0이면 사용 횟수 제한이 없습니다
+	MaxUses   int        `gorm:"not null;default:0" json:"maxUses"`
+	Uses      int        `gorm:"not null;default:0" json:"uses"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// Session은 한 번의 로그인(기기)입니다. ID는 그 로그인의 리프레시 토큰 패밀리 ID와 같고,
+// 액세스 토큰의 sid 클레임으로 연결되므로 세션을 폐기하면 토큰과 웹소켓 연결이 함께 끊깁니다
+type Session struct {
+	ID         string     `gorm:"primaryKey;size:64" json:"id"`
+	UserID     uint       `gorm:"not null;index" json:"userId"`
+	Device     string     `gorm:"size:100" json:"device"`
+	UserAgent  string     `gorm:"size:512" json:"userAgent"`
+	IP         string     `gorm:"size:64" json:"ip"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastSeenAt time.Time  `gorm:"not null" json:"lastSeenAt"`
+	ExpiresAt  time.Time  `gorm:"not null;index" json:"expiresAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+}
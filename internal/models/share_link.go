@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// RoomShareLink는 로그인하지 않은 사람이 게스트로 채팅방에 들어올 수 있는 공유 링크입니다.
+// 링크 토큰 원문은 저장하지 않고 해시만 저장합니다
+type RoomShareLink struct {
+	ID        uint   `gorm:"primarykey" json:"id"`
+	RoomID    uint   `gorm:"not null;index" json:"roomId"`
+	CreatedBy uint   `gorm:"not null" json:"createdBy"`
+	TokenHash string `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	// CanWrite가 false면 링크로 들어온 게스트는 메시지를 읽기만 할 수 있습니다
+	CanWrite  bool       `gorm:"not null;default:false" json:"canWrite"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
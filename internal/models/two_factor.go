@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// UserTwoFactor는 사용자의 TOTP 2단계 인증 설정입니다. EnabledAt이 nil이면 등록 확인 전입니다
+type UserTwoFactor struct {
+	UserID uint   `gorm:"primaryKey" json:"userId"`
+	Secret string `gorm:"size:64;not null" json:"-"`
+	// LastUsedStep은 마지막으로 사용된 코드의 시간 단계입니다. 같은 코드의 재사용을 막습니다
+	LastUsedStep int64      `gorm:"not null;default:0" json:"-"`
+	EnabledAt    *time.Time `json:"enabledAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+// RecoveryCode는 인증 앱을 사용할 수 없을 때 쓰는 일회용 복구 코드(해시)입니다
+type RecoveryCode struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"userId"`
+	CodeHash  string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
@@ -1,30 +1,51 @@
 package models
 
 import (
-	"golang.org/x/crypto/bcrypt"
+	"time"
+
 	"gorm.io/gorm"
 )
 
-type User struct {
-	gorm.Model
-	Username     string    `json:"username" gorm:"unique"`
-	Email        string    `json:"email" gorm:"unique"`
-	PasswordHash string    `json:"-"`
-	Messages     []Message `json:"messages"`
+// 시스템 전체 역할. 위 역할은 아래 역할의 권한을 모두 가집니다
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+var roleRanks = map[string]int{RoleUser: 0, RoleModerator: 1, RoleAdmin: 2}
+
+// ValidRole은 정의된 역할인지 확인합니다
+func ValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
 }
 
-// SetPassword는 비밀번호를 해시하여 저장합니다
-func (u *User) SetPassword(password string) error {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return err
-	}
-	u.PasswordHash = string(hashedPassword)
-	return nil
+// RoleAtLeast는 role이 required 이상의 역할인지 확인합니다
+func RoleAtLeast(role, required string) bool {
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[required]
 }
 
-// CheckPassword는 제공된 비밀번호가 저장된 해시와 일치하는지 확인합니다
-func (u *User) CheckPassword(password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
-	return err == nil
+type User struct {
+	gorm.Model
+	Username     string `json:"username" gorm:"unique"`
+	Email        string `json:"email" gorm:"unique"`
+	PasswordHash string `json:"-"`
+	// Role은 시스템 전체 역할입니다 (user, moderator, admin)
+	Role string `json:"role" gorm:"size:16;not null;default:user"`
+	// IsBot은 연동용 봇 사용자인지 나타냅니다. 봇은 비밀번호로 로그인할 수 없고 API 키로만 인증합니다
+	IsBot bool `json:"isBot" gorm:"not null;default:false"`
+	// OwnerID는 봇을 만든 사용자입니다
+	OwnerID *uint `json:"ownerId,omitempty" gorm:"index"`
+	// IsGuest는 공유 링크로 들어온 게스트인지 나타냅니다. 게스트는 한 채팅방에만 접근할 수 있고 ExpiresAt에 삭제됩니다
+	IsGuest   bool       `json:"isGuest" gorm:"not null;default:false"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" gorm:"index"`
+	// GuestRoomRole은 공유 링크가 게스트에게 부여한 채팅방 역할입니다. 게스트의 역할은 이보다 높일 수 없습니다
+	GuestRoomRole string `json:"-" gorm:"size:16"`
+	// EmailVerifiedAt은 이메일 인증 시각입니다. nil이면 인증되지 않은 주소입니다
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt"`
+	Messages        []Message  `json:"messages"`
+	// TokensRevokedAt 이전에 발급된 모든 토큰은 무효입니다 (전체 세션 폐기)
+	TokensRevokedAt *time.Time `json:"-"`
 }
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// UserIdentity는 외부 OIDC 공급자 계정(공급자 이름 + subject)과 로컬 사용자의 연결입니다
+type UserIdentity struct {
+	ID       uint   `gorm:"primarykey" json:"id"`
+	UserID   uint   `gorm:"not null;index" json:"userId"`
+	Provider string `gorm:"size:64;not null;uniqueIndex:idx_user_identities_provider_subject" json:"provider"`
+	Subject  string `gorm:"size:255;not null;uniqueIndex:idx_user_identities_provider_subject" json:"subject"`
+	// Email은 마지막 로그인 때 공급자가 확인한 이메일입니다
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// OIDCLoginState는 진행 중인 OIDC 로그인입니다.
+// 콜백이 다른 인스턴스로 들어와도 처리할 수 있도록 DB에 저장하며, 콜백에서 한 번만 사용됩니다
+type OIDCLoginState struct {
+	StateHash    string    `gorm:"size:64;primarykey" json:"-"`
+	Provider     string    `gorm:"size:64;not null" json:"provider"`
+	CodeVerifier string    `gorm:"not null" json:"-"`
+	Nonce        string    `gorm:"not null" json:"-"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expiresAt"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
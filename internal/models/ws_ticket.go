@@ -0,0 +1,33 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// WSTicket은 웹소켓 연결에 한 번 사용하는 짧은 수명의 티켓입니다.
+// 액세스 토큰이 URL(프록시, 접근 로그)에 남지 않도록 토큰 대신 사용하며, 연결을 받는 인스턴스가 다를 수 있어 DB에 저장합니다
+type WSTicket struct {
+	TicketHash string `gorm:"size:64;primarykey" json:"-"`
+	UserID     uint   `gorm:"not null;index" json:"userId"`
+	// TokenID는 티켓을 발급받은 액세스 토큰의 ID입니다. 그 토큰이 폐기되면 연결도 닫힙니다
+	TokenID   string `gorm:"size:64;not null" json:"-"`
+	SessionID string `gorm:"size:64" json:"-"`
+	// GuestRoomID는 게스트 토큰으로 발급된 티켓의 채팅방입니다
+	GuestRoomID uint `json:"-"`
+	// Scopes는 원래 토큰의 쉼표로 구분된 권한 범위입니다
+	Scopes string `gorm:"size:255" json:"-"`
+	// TokenExpiresAt은 원래 토큰의 만료 시각입니다 (티켓 자체의 만료는 ExpiresAt)
+	TokenExpiresAt int64     `json:"-"`
+	IP             string    `gorm:"size:64;not null" json:"-"`
+	ExpiresAt      time.Time `gorm:"not null;index" json:"expiresAt"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// ScopeList는 원래 토큰의 권한 범위 목록을 반환합니다
+func (t *WSTicket) ScopeList() []string {
+	if t.Scopes == "" {
+		return nil
+	}
+	return strings.Split(t.Scopes, ",")
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+	"mult-working/pkg/mailer"
+)
+
+// actionClaims는 용도가 정해진 서명 토큰(2단계 로그인 챌린지)의 페이로드입니다.
+// 액세스 토큰(TokenClaims)과 필드 이름이 겹치지 않으므로 액세스 토큰으로 사용할 수 없습니다.
+// 메일 링크 토큰은 public 모드에서 내용이 보이지 않도록 서명 토큰 대신 임의 토큰을 씁니다 (issueActionToken)
+type actionClaims struct {
+	Purpose   string `json:"purpose"`
+	Subject   uint   `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// AccountService는 이메일 인증과 비밀번호 재설정을 제공합니다
+type AccountService struct {
+	db              *gorm.DB
+	authService     *AuthService
+	mailer          mailer.Mailer
+	linkBaseURL     string
+	verificationTTL time.Duration
+	resetTTL        time.Duration
+
+	// pending은 백그라운드에서 진행 중인 메일 발송입니다
+	pending sync.WaitGroup
+}
+
+// NewAccountService는 새 AccountService 인스턴스를 생성합니다
+func NewAccountService(db *gorm.DB, cfg config.AuthConfig, authService *AuthService, m mailer.Mailer) *AccountService {
+	verificationTTL := cfg.VerificationTokenDuration
+	if verificationTTL <= 0 {
+		verificationTTL = 48 * time.Hour
+	}
+	resetTTL := cfg.PasswordResetTokenDuration
+	if resetTTL <= 0 {
+		resetTTL = 30 * time.Minute
+	}
+
+	return &AccountService{
+		db:              db,
+		authService:     authService,
+		mailer:          m,
+		linkBaseURL:     strings.TrimRight(cfg.LinkBaseURL, "/"),
+		verificationTTL: verificationTTL,
+		resetTTL:        resetTTL,
+	}
+}
+
+// SendVerification은 이메일 인증 링크를 백그라운드에서 보냅니다.
+// 응답 시간으로 주소 존재 여부를 알 수 없도록, 조회와 발송을 기다리지 않고 바로 반환합니다 (실패는 로그로 남김)
+func (s *AccountService) SendVerification(ctx context.Context, email string) {
+	s.deliverAsync(ctx, "verification", func(ctx context.Context) error {
+		return s.sendVerification(ctx, email)
+	})
+}
+
+// sendVerification은 인증 링크를 보냅니다. 없는 주소나 이미 인증된 주소면 아무것도 하지 않습니다
+func (s *AccountService) sendVerification(ctx context.Context, email string) error {
+	user, err := s.findByEmail(email)
+	if err != nil || user == nil || user.EmailVerifiedAt != nil {
+		return err
+	}
+
+	token, err := s.issueActionToken(user, models.ActionVerifyEmail, s.verificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s. If you did not create an account, ignore this email.\n",
+			user.Username, s.link("/verify-email", token), s.verificationTTL),
+	})
+}
+
+// VerifyEmail은 인증 토큰을 사용해 이메일을 인증 처리합니다
+func (s *AccountService) VerifyEmail(token string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		record, err := s.consumeActionToken(tx, token, models.ActionVerifyEmail)
+		if err != nil {
+			return err
+		}
+
+		// 토큰 발급 후 이메일이 바뀌었으면 새 주소는 인증되지 않음
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND email = ?", record.UserID, record.Email).
+			Update("email_verified_at", time.Now())
+		if result.Error != nil {
+			return errors.ErrDatabaseError
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrInvalidToken
+		}
+		return nil
+	})
+}
+
+// RequestPasswordReset은 비밀번호 재설정 링크를 백그라운드에서 보냅니다.
+// SendVerification과 마찬가지로 주소 존재 여부와 관계없이 바로 반환합니다
+func (s *AccountService) RequestPasswordReset(ctx context.Context, email string) {
+	s.deliverAsync(ctx, "password reset", func(ctx context.Context) error {
+		return s.sendPasswordReset(ctx, email)
+	})
+}
+
+// sendPasswordReset은 재설정 링크를 보냅니다. 없는 주소면 아무것도 하지 않습니다
+func (s *AccountService) sendPasswordReset(ctx context.Context, email string) error {
+	user, err := s.findByEmail(email)
+	if err != nil || user == nil {
+		return err
+	}
+
+	token, err := s.issueActionToken(user, models.ActionResetPassword, s.resetTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone requested a password reset for your account. Choose a new password here:\n\n%s\n\nThe link expires in %s and can be used once. If you did not request this, ignore this email.\n",
+			user.Username, s.link("/reset-password", token), s.resetTTL),
+	})
+}
+
+// ResetPassword는 재설정 토큰으로 비밀번호를 바꾸고 기존 세션을 모두 폐기합니다
+func (s *AccountService) ResetPassword(token, password string) error {
+	var userID uint
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		record, err := s.consumeActionToken(tx, token, models.ActionResetPassword)
+		if err != nil {
+			return err
+		}
+
+		var user models.User
+		if err := tx.First(&user, record.UserID).Error; err != nil {
+			return errors.ErrInvalidToken
+		}
+		hash, err := s.authService.hashNewPassword(password, user.Username)
+		if err != nil {
+			return err
+		}
+
+		// 메일의 링크를 열었으므로 주소도 인증된 것으로 처리
+		updates := map[string]interface{}{"password_hash": hash}
+		if user.EmailVerifiedAt == nil && user.Email == record.Email {
+			updates["email_verified_at"] = time.Now()
+		}
+		if err := tx.Model(&user).Updates(updates).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+
+		userID = user.ID
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.authService.RevokeAllSessions(userID)
+}
+
+// Wait는 백그라운드에서 진행 중인 메일 발송이 끝날 때까지 기다립니다
+func (s *AccountService) Wait() {
+	s.pending.Wait()
+}
+
+// deliverAsync는 메일 발송 작업을 요청과 분리해 실행합니다. 요청이 끝나도 취소되지 않습니다
+func (s *AccountService) deliverAsync(ctx context.Context, kind string, send func(context.Context) error) {
+	ctx = context.WithoutCancel(ctx)
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		if err := send(ctx); err != nil {
+			log.Printf("Failed to send %s email: %v", kind, err)
+		}
+	}()
+}
+
+func (s *AccountService) findByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := s.db.Where("LOWER(email) = ?", strings.ToLower(email)).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.ErrDatabaseError
+	}
+	return &user, nil
+}
+
+// issueActionToken은 임의의 일회용 토큰을 발급하고 해시만 저장합니다. 같은 용도로 이전에 발급한 토큰은 무효가 됩니다
+func (s *AccountService) issueActionToken(user *models.User, purpose string, ttl time.Duration) (string, error) {
+	token, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	record := models.ActionToken{
+		ID:        hashToken(token),
+		UserID:    user.ID,
+		Purpose:   purpose,
+		Email:     user.Email,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ActionToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", user.ID, purpose).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Create(&record).Error
+	})
+	if err != nil {
+		return "", errors.ErrDatabaseError
+	}
+
+	// 사용했거나 만료된 기록 정리
+	if err := s.db.Where("expires_at < ?", now).Delete(&models.ActionToken{}).Error; err != nil {
+		log.Printf("Failed to purge expired action tokens: %v", err)
+	}
+
+	return token, nil
+}
+
+// consumeActionToken은 토큰의 용도와 만료를 확인하고 사용 처리합니다. 같은 토큰은 한 번만 성공합니다
+func (s *AccountService) consumeActionToken(tx *gorm.DB, token, purpose string) (*models.ActionToken, error) {
+	var record models.ActionToken
+	if err := tx.Where("id = ? AND purpose = ? AND used_at IS NULL", hashToken(token), purpose).First(&record).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInvalidToken
+		}
+		return nil, errors.ErrDatabaseError
+	}
+	if time.Now().After(record.ExpiresAt) {
+		return nil, errors.ErrTokenExpired
+	}
+
+	result := tx.Model(&models.ActionToken{}).
+		Where("id = ? AND used_at IS NULL", record.ID).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return nil, errors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.ErrInvalidToken
+	}
+	return &record, nil
+}
+
+// link는 메일에 담을 클라이언트 링크를 만듭니다
+func (s *AccountService) link(path, token string) string {
+	return s.linkBaseURL + path + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+// AdminService는 서버 전체 관리 기능(사용자 조회, 역할 변경)을 제공합니다
+type AdminService struct {
+	db          *gorm.DB
+	authService *AuthService
+}
+
+// NewAdminService는 새 AdminService 인스턴스를 생성합니다
+func NewAdminService(db *gorm.DB, authService *AuthService) *AdminService {
+	return &AdminService{db: db, authService: authService}
+}
+
+// ListUsers는 사용자 목록을 ID 순으로 반환합니다
+func (s *AdminService) ListUsers(limit, offset int) ([]dto.AdminUserResponse, error) {
+	var users []models.User
+	if err := s.db.Order("id").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	resp := make([]dto.AdminUserResponse, 0, len(users))
+	for i := range users {
+		resp = append(resp, toAdminUserResponse(&users[i]))
+	}
+	return resp, nil
+}
+
+// SetRole은 사용자의 역할을 바꿉니다. 봇과 게스트에는 역할을 줄 수 없고, 마지막 관리자는 강등할 수 없습니다
+func (s *AdminService) SetRole(userID uint, role string) (*dto.AdminUserResponse, error) {
+	if !models.ValidRole(role) {
+		return nil, errors.ErrInvalidRequest
+	}
+
+	var user models.User
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&user, userID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrUserNotFound
+			}
+			return errors.ErrDatabaseError
+		}
+		if (user.IsBot || user.IsGuest) && role != models.RoleUser {
+			return errors.ErrInvalidRequest
+		}
+
+		if user.Role == models.RoleAdmin && role != models.RoleAdmin {
+			var admins int64
+			if err := tx.Model(&models.User{}).Where("role = ?", models.RoleAdmin).Count(&admins).Error; err != nil {
+				return errors.ErrDatabaseError
+			}
+			if admins <= 1 {
+				return errors.ErrLastAdmin
+			}
+		}
+
+		if err := tx.Model(&user).Update("role", role).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toAdminUserResponse(&user)
+	return &resp, nil
+}
+
+// RevokeSessions는 사용자의 모든 토큰과 세션을 폐기합니다
+func (s *AdminService) RevokeSessions(userID uint) error {
+	var count int64
+	if err := s.db.Model(&models.User{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+	if count == 0 {
+		return errors.ErrUserNotFound
+	}
+	return s.authService.RevokeAllSessions(userID)
+}
+
+// BootstrapAdmin은 관리자 계정을 만듭니다. 같은 이름의 사용자가 이미 있으면 관리자로 승격합니다.
+// 명령줄 도구에서 첫 관리자를 만들 때 사용하며, 반환값은 새로 만들었는지 여부입니다
+func (s *AdminService) BootstrapAdmin(username, email, password string) (*models.User, bool, error) {
+	var user models.User
+	err := s.db.Where("username = ?", username).First(&user).Error
+	if err == nil {
+		// SetRole과 마찬가지로 봇과 게스트는 관리자가 될 수 없음
+		if user.IsBot || user.IsGuest {
+			return nil, false, errors.ErrInvalidRequest
+		}
+		if err := s.db.Model(&user).Update("role", models.RoleAdmin).Error; err != nil {
+			return nil, false, errors.ErrDatabaseError
+		}
+		return &user, false, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, false, errors.ErrDatabaseError
+	}
+
+	if email == "" || password == "" {
+		return nil, false, errors.ErrInvalidRequest
+	}
+	hash, err := s.authService.hashNewPassword(password, username)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var count int64
+	if err := s.db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return nil, false, errors.ErrDatabaseError
+	}
+	if count > 0 {
+		return nil, false, errors.ErrUserExists
+	}
+
+	// 운영자가 직접 만든 계정이므로 이메일은 인증된 것으로 처리
+	now := time.Now()
+	user = models.User{
+		Username:        username,
+		Email:           email,
+		Role:            models.RoleAdmin,
+		PasswordHash:    hash,
+		EmailVerifiedAt: &now,
+	}
+	if err := s.db.Create(&user).Error; err != nil {
+		return nil, false, errors.ErrDatabaseError
+	}
+	return &user, true, nil
+}
+
+func toAdminUserResponse(user *models.User) dto.AdminUserResponse {
+	return dto.AdminUserResponse{
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Role:          user.Role,
+		IsBot:         user.IsBot,
+		IsGuest:       user.IsGuest,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt,
+	}
+}
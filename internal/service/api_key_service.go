@@ -0,0 +1,250 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+const (
+	// apiKeyPrefix는 발급하는 API 키의 접두사입니다. 로그나 저장소에 유출된 키를 찾기 쉽게 합니다
+	apiKeyPrefix = "mwk_"
+	// apiKeyDisplayLength는 목록에 보여 줄 키 앞부분의 길이입니다
+	apiKeyDisplayLength = 12
+	// botEmailDomain은 봇 사용자에게 부여하는 예약된 도메인입니다 (메일이 전달되지 않음)
+	botEmailDomain = "bots.invalid"
+	// lastUsedResolution보다 자주 사용돼도 마지막 사용 시각은 한 번만 갱신합니다
+	lastUsedResolution = time.Minute
+)
+
+// APIKeyService는 봇 사용자와 봇의 API 키를 관리하고 키를 인증합니다
+type APIKeyService struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyService는 새 APIKeyService 인스턴스를 생성합니다
+func NewAPIKeyService(db *gorm.DB) *APIKeyService {
+	return &APIKeyService{db: db}
+}
+
+// CreateBot은 ownerID 사용자가 관리하는 봇 사용자를 만듭니다. 봇은 비밀번호가 없어 로그인할 수 없습니다
+func (s *APIKeyService) CreateBot(ownerID uint, req dto.CreateBotRequest) (*dto.BotResponse, error) {
+	var owner models.User
+	if err := s.db.First(&owner, ownerID).Error; err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+	if owner.IsBot {
+		return nil, errors.ErrPermissionDenied
+	}
+
+	email := strings.ToLower(req.Username) + "@" + botEmailDomain
+	var count int64
+	if err := s.db.Model(&models.User{}).Where("username = ? OR email = ?", req.Username, email).Count(&count).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+	if count > 0 {
+		return nil, errors.ErrUserExists
+	}
+
+	bot := models.User{
+		Username: req.Username,
+		Email:    email,
+		IsBot:    true,
+		OwnerID:  &owner.ID,
+	}
+	if err := s.db.Create(&bot).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	return toBotResponse(&bot), nil
+}
+
+// ListBots는 사용자가 관리하는 봇 목록을 반환합니다
+func (s *APIKeyService) ListBots(ownerID uint) ([]dto.BotResponse, error) {
+	var bots []models.User
+	if err := s.db.Where("owner_id = ? AND is_bot = ?", ownerID, true).Order("id").Find(&bots).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	resp := make([]dto.BotResponse, 0, len(bots))
+	for i := range bots {
+		resp = append(resp, *toBotResponse(&bots[i]))
+	}
+	return resp, nil
+}
+
+// DeleteBot은 봇을 삭제하고 봇의 API 키를 모두 폐기합니다
+func (s *APIKeyService) DeleteBot(ownerID, botID uint) error {
+	bot, err := s.findBot(ownerID, botID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.APIKey{}).
+			Where("user_id = ? AND revoked_at IS NULL", bot.ID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if err := tx.Delete(bot).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		return nil
+	})
+}
+
+// CreateKey는 봇의 새 API 키를 발급합니다. 키 원문은 응답에만 담기고 저장되지 않습니다
+func (s *APIKeyService) CreateKey(ownerID, botID uint, req dto.CreateAPIKeyRequest) (*dto.CreatedAPIKeyResponse, error) {
+	bot, err := s.findBot(ownerID, botID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	raw := apiKeyPrefix + secret
+
+	key := models.APIKey{
+		UserID:  bot.ID,
+		Name:    req.Name,
+		Prefix:  raw[:apiKeyDisplayLength],
+		KeyHash: hashToken(raw),
+		Scopes:  strings.Join(scopes, ","),
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		key.ExpiresAt = &expiresAt
+	}
+	if err := s.db.Create(&key).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	return &dto.CreatedAPIKeyResponse{APIKeyResponse: *toAPIKeyResponse(&key), Key: raw}, nil
+}
+
+// ListKeys는 봇의 API 키 목록을 반환합니다 (폐기된 키 포함)
+func (s *APIKeyService) ListKeys(ownerID, botID uint) ([]dto.APIKeyResponse, error) {
+	bot, err := s.findBot(ownerID, botID)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []models.APIKey
+	if err := s.db.Where("user_id = ?", bot.ID).Order("id").Find(&keys).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	resp := make([]dto.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		resp = append(resp, *toAPIKeyResponse(&keys[i]))
+	}
+	return resp, nil
+}
+
+// RevokeKey는 봇의 API 키를 폐기합니다. 폐기된 키는 즉시 인증에 실패합니다
+func (s *APIKeyService) RevokeKey(ownerID, botID, keyID uint) error {
+	bot, err := s.findBot(ownerID, botID)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", keyID, bot.ID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return errors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// Authenticate는 API 키를 확인하고 키 정보를 반환합니다. 폐기, 만료되었거나 봇이 삭제된 키는 거부합니다
+func (s *APIKeyService) Authenticate(raw string) (*models.APIKey, error) {
+	if !strings.HasPrefix(raw, apiKeyPrefix) {
+		return nil, errors.ErrInvalidAPIKey
+	}
+
+	var key models.APIKey
+	err := s.db.Joins("JOIN users ON users.id = api_keys.user_id AND users.deleted_at IS NULL AND users.is_bot = ?", true).
+		Where("api_keys.key_hash = ? AND api_keys.revoked_at IS NULL", hashToken(raw)).
+		First(&key).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInvalidAPIKey
+		}
+		return nil, errors.ErrDatabaseError
+	}
+
+	now := time.Now()
+	if key.ExpiresAt != nil && now.After(*key.ExpiresAt) {
+		return nil, errors.ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.db.Model(&key).Update("last_used_at", now).Error; err == nil {
+			key.LastUsedAt = &now
+		}
+	}
+	return &key, nil
+}
+
+func (s *APIKeyService) findBot(ownerID, botID uint) (*models.User, error) {
+	var bot models.User
+	if err := s.db.Where("id = ? AND owner_id = ? AND is_bot = ?", botID, ownerID, true).First(&bot).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrBotNotFound
+		}
+		return nil, errors.ErrDatabaseError
+	}
+	return &bot, nil
+}
+
+// normalizeScopes는 요청된 권한 범위를 확인하고 중복을 없앱니다
+func normalizeScopes(requested []string) ([]string, error) {
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		if !slices.Contains(models.APIKeyScopes, scope) {
+			return nil, errors.ErrInvalidRequest
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	slices.Sort(scopes)
+	return scopes, nil
+}
+
+func toBotResponse(bot *models.User) *dto.BotResponse {
+	return &dto.BotResponse{
+		ID:        bot.ID,
+		Username:  bot.Username,
+		CreatedAt: bot.CreatedAt,
+	}
+}
+
+func toAPIKeyResponse(key *models.APIKey) *dto.APIKeyResponse {
+	return &dto.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.ScopeList(),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
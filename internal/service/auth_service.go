@@ -1,41 +1,118 @@
 package service
 
 import (
+	errs "errors"
+	"log"
+	"mult-working/internal/config"
 	"mult-working/internal/dto"
 	"mult-working/internal/errors"
+	"mult-working/internal/events"
 	"mult-working/internal/models"
+	"mult-working/pkg/password"
+	"sync"
 	"time"
 
-	"github.com/o1egl/paseto"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// twoFactorChallengePurpose는 비밀번호 확인 후 2단계 코드를 기다리는 챌린지 토큰의 용도입니다
+const twoFactorChallengePurpose = "login_2fa"
+
 // AuthService는 인증 관련 기능을 제공합니다
 type AuthService struct {
-	db            *gorm.DB
-	symmetricKey  []byte
-	tokenDuration time.Duration
-	paseto        *paseto.V2
+	db                   *gorm.DB
+	tokenDuration        time.Duration
+	refreshTokenDuration time.Duration
+	tokens               tokenCodec
+	publisher            events.Publisher
+	revocations          *RevocationStore
+	requireVerifiedEmail bool
+	twoFactor            *TwoFactorService
+	throttle             *LoginThrottle
+	apiKeys              *APIKeyService
+	challengeDuration    time.Duration
+	wsTicketDuration     time.Duration
+	allowWSQueryToken    bool
+	guestDuration        time.Duration
+	passwords            *password.Hasher
+	passwordPolicy       *password.Policy
+	// authenticators는 비밀번호 로그인을 확인할 인증 백엔드입니다 (설정된 순서대로 시도)
+	authenticators []Authenticator
+	// dummyPasswordHash는 없는 사용자로 로그인할 때 비교에 쓰는 해시입니다. 처음 필요할 때 한 번만 계산합니다
+	dummyPasswordHash func() string
+
+	// sessionTouches는 세션별 마지막 접속 시각 갱신 시각입니다 (요청마다 DB에 쓰지 않도록)
+	touchMu        sync.Mutex
+	sessionTouches map[string]time.Time
 }
 
 // NewAuthService는 새로운 AuthService 인스턴스를 생성합니다
-func NewAuthService(db *gorm.DB, symmetricKey []byte, tokenDuration time.Duration) *AuthService {
-	// 키 길이 확인 및 조정 (chacha20poly1305는 32바이트 키 필요)
-	if len(symmetricKey) != 32 {
-		// 키가 32바이트가 아닌 경우 조정
-		newKey := make([]byte, 32)
-		copy(newKey, symmetricKey)
-		// 키가 짧으면 0으로 채우고, 길면 자름
-		symmetricKey = newKey
+func NewAuthService(db *gorm.DB, cfg config.AuthConfig, publisher events.Publisher) (*AuthService, error) {
+	tokenDuration := cfg.TokenDuration
+	if tokenDuration <= 0 {
+		tokenDuration = 15 * time.Minute
+	}
+	refreshTokenDuration := cfg.RefreshTokenDuration
+	if refreshTokenDuration <= 0 {
+		refreshTokenDuration = 30 * 24 * time.Hour
+	}
+
+	challengeDuration := cfg.TwoFactorChallengeDuration
+	if challengeDuration <= 0 {
+		challengeDuration = 5 * time.Minute
+	}
+
+	wsTicketDuration := cfg.WSTicketDuration
+	if wsTicketDuration <= 0 {
+		wsTicketDuration = 30 * time.Second
+	}
+
+	guestDuration := cfg.GuestDuration
+	if guestDuration <= 0 {
+		guestDuration = 24 * time.Hour
+	}
+
+	passwordPolicy, err := password.NewPolicy(cfg.Password)
+	if err != nil {
+		return nil, err
+	}
+	passwords := password.NewHasher(cfg.Password.Argon2)
+
+	// 키 길이나 엔트로피가 부족하면 시작 단계에서 실패
+	tokens, err := newTokenCodec(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	return &AuthService{
-		db:            db,
-		symmetricKey:  symmetricKey,
-		tokenDuration: tokenDuration,
-		paseto:        paseto.NewV2(),
+	s := &AuthService{
+		db:                   db,
+		tokenDuration:        tokenDuration,
+		refreshTokenDuration: refreshTokenDuration,
+		tokens:               tokens,
+		publisher:            publisher,
+		revocations:          NewRevocationStore(db),
+		requireVerifiedEmail: cfg.RequireVerifiedEmail,
+		twoFactor:            NewTwoFactorService(db, cfg.TOTPIssuer),
+		throttle:             NewLoginThrottle(db, cfg.LoginThrottle),
+		apiKeys:              NewAPIKeyService(db),
+		challengeDuration:    challengeDuration,
+		wsTicketDuration:     wsTicketDuration,
+		allowWSQueryToken:    cfg.AllowWSQueryToken,
+		guestDuration:        guestDuration,
+		passwords:            passwords,
+		passwordPolicy:       passwordPolicy,
+		dummyPasswordHash: sync.OnceValue(func() string {
+			hash, _ := passwords.Hash("dummy-password-for-timing")
+			return hash
+		}),
+		sessionTouches: make(map[string]time.Time),
 	}
+
+	s.authenticators, err = newAuthenticators(s, db, cfg, publisher)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // Register는 새 사용자를 등록합니다
@@ -49,8 +126,8 @@ func (s *AuthService) Register(req dto.RegisterRequest) error {
 		return errors.ErrUserExists
 	}
 
-	// 비밀번호 해싱
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	// 비밀번호 정책 확인 및 해싱
+	hashedPassword, err := s.hashNewPassword(req.Password, req.Username)
 	if err != nil {
 		return err
 	}
@@ -59,56 +136,396 @@ func (s *AuthService) Register(req dto.RegisterRequest) error {
 	user := models.User{
 		Username:     req.Username,
 		Email:        req.Email,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 	}
 
-	return s.db.Create(&user).Error
+	if err := s.db.Create(&user).Error; err != nil {
+		return err
+	}
+
+	events.PublishOrLog(s.publisher, events.New(events.TypeUserRegistered, 1, events.UserKey(user.ID), events.UserRegistered{
+		UserID:   user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+	}))
+
+	return nil
 }
 
-// Login은 사용자를 인증하고 PASETO 토큰을 반환합니다
-func (s *AuthService) Login(req dto.LoginRequest) (*dto.TokenResponse, error) {
+// Login은 사용자를 인증하고 PASETO 토큰을 반환합니다.
+// 2단계 인증을 사용하는 사용자에게는 토큰 대신 챌린지 토큰을 반환하며, LoginTwoFactor로 코드를 확인해야 토큰이 발급됩니다.
+// 사용자 이름을 추측할 수 없도록 없는 사용자와 틀린 비밀번호는 같은 에러를 반환합니다
+func (s *AuthService) Login(req dto.LoginRequest, client ClientInfo) (*dto.LoginResponse, error) {
+	var found *models.User
 	var user models.User
-	if result := s.db.Where("username = ? OR email = ?", req.Username, req.Username).First(&user); result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return nil, errors.ErrUserNotFound
-		}
-		return nil, result.Error
+	if result := s.db.Where("username = ? OR email = ?", req.Username, req.Username).First(&user); result.Error == nil {
+		found = &user
+	} else if result.Error != gorm.ErrRecordNotFound {
+		return nil, errors.ErrDatabaseError
 	}
 
-	if !user.CheckPassword(req.Password) {
+	key := accountKey(found, req.Username)
+	if err := s.throttle.Check(key, client.IP); err != nil {
+		s.throttle.Record(key, req.Username, found, client, models.LoginThrottled)
+		return nil, err
+	}
+
+	// 봇은 API 키로만, 게스트는 공유 링크로만 인증
+	if found != nil && (user.IsBot || user.IsGuest) {
+		s.passwords.Verify(req.Password, s.dummyPasswordHash())
+		s.throttle.Record(key, req.Username, found, client, models.LoginInvalidCredentials)
 		return nil, errors.ErrInvalidCredentials
 	}
+	authenticated, err := s.authenticate(found, req.Username, req.Password)
+	if err != nil {
+		if errs.Is(err, errors.ErrInvalidCredentials) {
+			s.throttle.Record(key, req.Username, found, client, models.LoginInvalidCredentials)
+		}
+		return nil, err
+	}
+	// 디렉터리 사용자는 처음 로그인할 때 로컬 사용자가 만들어짐
+	user = *authenticated
+	found = &user
 
-	// PASETO 토큰 생성
-	now := time.Now()
-	expiration := now.Add(s.tokenDuration)
+	if s.requireVerifiedEmail && user.EmailVerifiedAt == nil {
+		s.throttle.Record(key, req.Username, found, client, models.LoginEmailNotVerified)
+		return nil, errors.ErrEmailNotVerified
+	}
+
+	enabled, err := s.twoFactor.Enabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		expiresAt := time.Now().Add(s.challengeDuration)
+		challenge, err := s.tokens.encode(actionClaims{
+			Purpose:   twoFactorChallengePurpose,
+			Subject:   user.ID,
+			ExpiresAt: expiresAt.Unix(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		// 2단계 코드까지 확인해야 성공으로 기록하므로 이전 실패 횟수는 유지됨
+		s.throttle.Record(key, req.Username, found, client, models.LoginTwoFactorRequired)
+		return &dto.LoginResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     challenge,
+			ChallengeExpiresAt: &expiresAt,
+		}, nil
+	}
+
+	tokens, err := s.StartSession(user.ID, client)
+	if err != nil {
+		return nil, err
+	}
+	s.throttle.Record(key, req.Username, found, client, models.LoginSucceeded)
+	return &dto.LoginResponse{TokenResponse: tokens}, nil
+}
+
+// authenticate는 설정된 인증 백엔드를 차례로 시도합니다.
+// 모든 백엔드가 자격 증명을 거부하면 ErrInvalidCredentials를, 그 밖의 실패가 있었으면 그 에러를 반환합니다
+func (s *AuthService) authenticate(found *models.User, username, password string) (*models.User, error) {
+	var failure error
+	for _, authenticator := range s.authenticators {
+		user, err := authenticator.Authenticate(found, username, password)
+		if err == nil {
+			return user, nil
+		}
+		if !errs.Is(err, errors.ErrInvalidCredentials) {
+			log.Printf("%s authenticator failed: %v", authenticator.Name(), err)
+			failure = err
+		}
+	}
+	if failure != nil {
+		return nil, failure
+	}
+	return nil, errors.ErrInvalidCredentials
+}
+
+// hashNewPassword는 새 비밀번호가 정책을 지키는지 확인하고 해시를 반환합니다
+func (s *AuthService) hashNewPassword(plain, username string) (string, error) {
+	if err := s.passwordPolicy.Validate(plain, username); err != nil {
+		return "", err
+	}
+	return s.passwords.Hash(plain)
+}
+
+// checkPassword는 비밀번호를 확인하고, 저장된 해시가 bcrypt이거나 이전 파라미터로 만들어졌으면 새 해시로 바꿉니다
+func (s *AuthService) checkPassword(user *models.User, plain string) bool {
+	ok, needsRehash := s.passwords.Verify(plain, user.PasswordHash)
+	if !ok || !needsRehash {
+		return ok
+	}
+
+	hash, err := s.passwords.Hash(plain)
+	if err != nil {
+		log.Printf("Failed to rehash password of user %d: %v", user.ID, err)
+		return true
+	}
+	// 그사이 비밀번호가 바뀌었으면 덮어쓰지 않음
+	result := s.db.Model(&models.User{}).
+		Where("id = ? AND password_hash = ?", user.ID, user.PasswordHash).
+		Update("password_hash", hash)
+	if result.Error != nil {
+		log.Printf("Failed to rehash password of user %d: %v", user.ID, result.Error)
+		return true
+	}
+	user.PasswordHash = hash
+	return true
+}
+
+// LoginTwoFactor는 챌린지 토큰과 인증 앱 코드(또는 복구 코드)를 확인하고 토큰을 발급합니다.
+// 틀린 코드는 비밀번호 실패와 같은 계정 시도 횟수 제한에 포함됩니다
+func (s *AuthService) LoginTwoFactor(req dto.TwoFactorLoginRequest, client ClientInfo) (*dto.TokenResponse, error) {
+	var claims actionClaims
+	if err := s.tokens.decode(req.ChallengeToken, &claims); err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+	if claims.Purpose != twoFactorChallengePurpose || claims.Subject == 0 {
+		return nil, errors.ErrInvalidToken
+	}
+	if time.Now().Unix() > claims.ExpiresAt {
+		return nil, errors.ErrTokenExpired
+	}
+
+	user := &models.User{}
+	user.ID = claims.Subject
+	key := accountKey(user, "")
+	if err := s.throttle.Check(key, client.IP); err != nil {
+		s.throttle.Record(key, "", user, client, models.LoginThrottled)
+		return nil, err
+	}
+
+	if err := s.twoFactor.Verify(claims.Subject, req.Code); err != nil {
+		if errs.Is(err, errors.ErrInvalidTwoFactor) {
+			s.throttle.Record(key, "", user, client, models.LoginInvalidTwoFactor)
+		}
+		return nil, err
+	}
+
+	tokens, err := s.StartSession(claims.Subject, client)
+	if err != nil {
+		return nil, err
+	}
+	s.throttle.Record(key, "", user, client, models.LoginSucceeded)
+	return tokens, nil
+}
+
+// TwoFactor는 2단계 인증 등록과 관리를 위한 TwoFactorService를 반환합니다
+func (s *AuthService) TwoFactor() *TwoFactorService {
+	return s.twoFactor
+}
+
+// UserRole은 사용자의 현재 시스템 역할을 반환합니다. 역할 변경이 바로 반영되도록 토큰에 담지 않고 매번 조회합니다
+func (s *AuthService) UserRole(userID uint) (string, error) {
+	var roles []string
+	if err := s.db.Model(&models.User{}).Where("id = ?", userID).Limit(1).Pluck("role", &roles).Error; err != nil {
+		return "", errors.ErrDatabaseError
+	}
+	if len(roles) == 0 {
+		return "", errors.ErrUserNotFound
+	}
+	return roles[0], nil
+}
+
+// APIKeys는 봇 사용자와 API 키 관리를 위한 APIKeyService를 반환합니다
+func (s *AuthService) APIKeys() *APIKeyService {
+	return s.apiKeys
+}
 
-	// v2.local 토큰 (대칭 암호화)
-	token, err := s.GenerateToken(user.ID)
+// StartSession은 이미 인증된 사용자의 새 세션을 만들고, 세션의 리프레시 토큰 패밀리로 토큰을 발급합니다 (비밀번호 로그인, SSO 로그인)
+func (s *AuthService) StartSession(userID uint, client ClientInfo) (*dto.TokenResponse, error) {
+	familyID, err := randomToken(16)
 	if err != nil {
 		return nil, err
 	}
 
+	var resp *dto.TokenResponse
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		session := models.Session{
+			ID:         familyID,
+			UserID:     userID,
+			Device:     describeDevice(client.UserAgent),
+			UserAgent:  truncate(client.UserAgent, 512),
+			IP:         client.IP,
+			LastSeenAt: now,
+			ExpiresAt:  now.Add(s.refreshTokenDuration),
+		}
+		if err := tx.Create(&session).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+
+		var err error
+		resp, err = s.issueTokens(tx, userID, familyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.purgeExpiredSessions()
+	return resp, nil
+}
+
+// Refresh는 리프레시 토큰을 회전시키고 새 액세스 토큰을 발급합니다.
+// 이미 사용된 리프레시 토큰이 다시 제출되면 탈취로 간주하고 같은 패밀리의 모든 토큰을 폐기합니다
+func (s *AuthService) Refresh(req dto.RefreshRequest, client ClientInfo) (*dto.TokenResponse, error) {
+	var resp *dto.TokenResponse
+	reused := false
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var stored models.RefreshToken
+		if err := tx.Where("token_hash = ?", hashToken(req.RefreshToken)).First(&stored).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrInvalidToken
+			}
+			return errors.ErrDatabaseError
+		}
+
+		if stored.RevokedAt != nil {
+			return errors.ErrInvalidToken
+		}
+		if time.Now().After(stored.ExpiresAt) {
+			return errors.ErrTokenExpired
+		}
+
+		// 사용되지 않은 토큰일 때만 사용 처리 (동시 요청도 하나만 성공)
+		now := time.Now()
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", stored.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return errors.ErrDatabaseError
+		}
+		if result.RowsAffected == 0 {
+			// 재사용 감지: 사용 처리와 같은 트랜잭션에서 패밀리 전체 폐기
+			reused = true
+			return s.revokeRefreshFamily(tx, stored.FamilyID)
+		}
+
+		var err error
+		resp, err = s.issueTokens(tx, stored.UserID, stored.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		// 세션의 마지막 접속 정보와 만료 시각 갱신
+		if err := tx.Model(&models.Session{}).Where("id = ?", stored.FamilyID).Updates(map[string]interface{}{
+			"last_seen_at": now,
+			"expires_at":   resp.RefreshExpiresAt,
+			"ip":           client.IP,
+			"user_agent":   truncate(client.UserAgent, 512),
+			"device":       describeDevice(client.UserAgent),
+		}).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, errors.ErrTokenReused
+	}
+
+	return resp, nil
+}
+
+// issueTokens는 액세스 토큰과 지정된 패밀리의 새 리프레시 토큰을 발급합니다
+func (s *AuthService) issueTokens(db *gorm.DB, userID uint, familyID string) (*dto.TokenResponse, error) {
+	token, err := s.GenerateToken(userID, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stored := models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: now.Add(s.refreshTokenDuration),
+	}
+	if err := db.Create(&stored).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
 	return &dto.TokenResponse{
-		Token:     token,
-		ExpiresAt: expiration,
+		Token:            token,
+		ExpiresAt:        now.Add(s.tokenDuration),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
 	}, nil
 }
 
-// GenerateToken은 사용자 ID로 JWT 토큰을 생성합니다
-func (s *AuthService) GenerateToken(userID uint) (string, error) {
+// revokeRefreshFamily는 패밀리에 속한 모든 리프레시 토큰을 폐기합니다
+func (s *AuthService) revokeRefreshFamily(db *gorm.DB, familyID string) error {
+	if err := db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+	return nil
+}
+
+// TokenClaims는 액세스 토큰 페이로드입니다
+type TokenClaims struct {
+	UserID  uint   `json:"user_id"`
+	TokenID string `json:"jti"`
+	// SessionID는 토큰이 속한 로그인 세션(리프레시 토큰 패밀리)입니다
+	SessionID string `json:"sid,omitempty"`
+	IssuedAt  int64  `json:"issued_at"`
+	// IssuedAtNano는 나노초 단위 발급 시각입니다. 같은 초 안의 전체 폐기와 재로그인을 구분하는 데 씁니다 (이전 토큰에는 없음)
+	IssuedAtNano int64 `json:"issued_at_ns,omitempty"`
+	ExpiresAt    int64 `json:"expires_at"`
+	// GuestRoomID는 게스트 토큰이 접근할 수 있는 유일한 채팅방입니다. 0이면 일반 사용자 토큰입니다
+	GuestRoomID uint `json:"guest_room,omitempty"`
+	// Scopes는 게스트 토큰의 권한 범위입니다 (API 키와 같은 범위 사용)
+	Scopes []string `json:"scp,omitempty"`
+}
+
+// issuedAt은 토큰 발급 시각을 가능한 가장 정밀하게 반환합니다
+func (c *TokenClaims) issuedAt() time.Time {
+	if c.IssuedAtNano != 0 {
+		return time.Unix(0, c.IssuedAtNano)
+	}
+	return time.Unix(c.IssuedAt, 0)
+}
+
+// IsGuest는 게스트 토큰인지 확인합니다
+func (c *TokenClaims) IsGuest() bool {
+	return c.GuestRoomID != 0
+}
+
+// GenerateToken은 사용자 ID와 세션 ID로 PASETO 토큰을 생성합니다
+func (s *AuthService) GenerateToken(userID uint, sessionID string) (string, error) {
 	now := time.Now()
 	exp := now.Add(s.tokenDuration)
 
+	tokenID, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+
 	// 토큰 페이로드
-	payload := map[string]interface{}{
-		"user_id":    userID,
-		"issued_at":  now.Unix(),
-		"expires_at": exp.Unix(),
+	payload := TokenClaims{
+		UserID:       userID,
+		TokenID:      tokenID,
+		SessionID:    sessionID,
+		IssuedAt:     now.Unix(),
+		IssuedAtNano: now.UnixNano(),
+		ExpiresAt:    exp.Unix(),
 	}
 
-	// 토큰 생성
-	token, err := s.paseto.Encrypt(s.symmetricKey, payload, nil)
+	// 토큰 생성 (모드에 따라 v2.local 암호화 또는 v2.public 서명)
+	token, err := s.tokens.encode(payload)
 	if err != nil {
 		return "", err
 	}
@@ -118,29 +535,99 @@ func (s *AuthService) GenerateToken(userID uint) (string, error) {
 
 // VerifyToken은 PASETO 토큰을 검증하고 사용자 ID를 반환합니다
 func (s *AuthService) VerifyToken(token string) (uint, error) {
-	var payload map[string]interface{}
+	claims, err := s.VerifyTokenClaims(token)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
+}
+
+// VerifyTokenClaims는 PASETO 토큰을 검증하고 폐기 여부를 확인한 뒤 클레임을 반환합니다
+func (s *AuthService) VerifyTokenClaims(token string) (*TokenClaims, error) {
+	var claims TokenClaims
 
 	// 토큰 검증
-	err := s.paseto.Decrypt(token, s.symmetricKey, &payload, nil)
-	if err != nil {
-		return 0, errors.ErrInvalidToken
+	if err := s.tokens.decode(token, &claims); err != nil {
+		return nil, errors.ErrInvalidToken
 	}
 
 	// 만료 시간 확인
-	expiresAt, ok := payload["expires_at"].(float64)
-	if !ok {
-		return 0, errors.ErrInvalidToken
+	if claims.ExpiresAt == 0 || claims.UserID == 0 {
+		return nil, errors.ErrInvalidToken
+	}
+	if time.Now().Unix() > claims.ExpiresAt {
+		return nil, errors.ErrTokenExpired
 	}
 
-	if time.Now().Unix() > int64(expiresAt) {
-		return 0, errors.ErrTokenExpired
+	// 폐기 여부 확인
+	revoked, err := s.revocations.IsRevoked(&claims)
+	if err != nil {
+		return nil, err
 	}
+	if revoked {
+		return nil, errors.ErrTokenRevoked
+	}
+
+	s.touchSession(claims.SessionID)
+	return &claims, nil
+}
 
-	// 사용자 ID 추출
-	userID, ok := payload["user_id"].(float64)
-	if !ok {
-		return 0, errors.ErrInvalidToken
+// Logout은 현재 액세스 토큰과 세션을 폐기하고, 리프레시 토큰이 주어지면 그 패밀리도 폐기합니다
+func (s *AuthService) Logout(claims *TokenClaims, refreshToken string) error {
+	if claims.SessionID != "" {
+		if err := s.RevokeSession(claims.UserID, claims.SessionID); err != nil && !errs.Is(err, errors.ErrSessionNotFound) {
+			return err
+		}
 	}
 
-	return uint(userID), nil
+	if refreshToken != "" {
+		var stored models.RefreshToken
+		err := s.db.Where("token_hash = ? AND user_id = ?", hashToken(refreshToken), claims.UserID).First(&stored).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return errors.ErrDatabaseError
+		}
+		if err == nil {
+			if err := s.revokeRefreshFamily(s.db, stored.FamilyID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if claims.TokenID == "" {
+		// jti가 없는 이전 형식의 토큰은 개별 폐기할 수 없음
+		return nil
+	}
+	return s.revocations.RevokeToken(claims)
+}
+
+// RevokeAllSessions는 사용자의 모든 세션과 액세스 토큰, 리프레시 토큰을 폐기합니다
+func (s *AuthService) RevokeAllSessions(userID uint) error {
+	now := time.Now()
+	if err := s.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+	if err := s.db.Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+
+	return s.revocations.RevokeUser(userID)
+}
+
+// PublicKeys는 토큰 검증용 공개 키 목록을 반환합니다. local 모드에서는 비어 있습니다
+func (s *AuthService) PublicKeys() []PublicKey {
+	return s.tokens.publicKeys()
+}
+
+// OnRevoke는 토큰이 폐기될 때 호출될 리스너를 등록합니다
+func (s *AuthService) OnRevoke(listener RevocationListener) {
+	s.revocations.OnRevoke(listener)
+}
+
+// InvalidateRevocations는 폐기 여부 캐시를 비웁니다. 다른 인스턴스의 폐기 알림을 받으면 호출합니다
+func (s *AuthService) InvalidateRevocations() {
+	s.revocations.Invalidate()
 }
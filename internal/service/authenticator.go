@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+)
+
+// 인증 백엔드 이름 (auth.authenticators 설정 값)
+const (
+	AuthenticatorDatabase = "database"
+	AuthenticatorLDAP     = "ldap"
+)
+
+// Authenticator는 비밀번호 로그인을 확인하는 인증 백엔드입니다
+type Authenticator interface {
+	// Name은 설정과 로그에 쓰는 백엔드 이름입니다
+	Name() string
+	// Authenticate는 자격 증명을 확인하고 로그인할 로컬 사용자를 반환합니다.
+	// found는 입력한 이름이나 이메일로 찾은 로컬 사용자이며, 없으면 nil입니다.
+	// 이 백엔드의 사용자가 아니거나 비밀번호가 틀리면 ErrInvalidCredentials를 반환하고, 다음 백엔드를 시도합니다
+	Authenticate(found *models.User, username, password string) (*models.User, error)
+}
+
+// newAuthenticators는 설정된 순서대로 인증 백엔드를 만듭니다. 설정이 없으면 데이터베이스 인증만 사용합니다
+func newAuthenticators(s *AuthService, db *gorm.DB, cfg config.AuthConfig, publisher events.Publisher) ([]Authenticator, error) {
+	names := cfg.Authenticators
+	if len(names) == 0 {
+		names = []string{AuthenticatorDatabase}
+	}
+
+	authenticators := make([]Authenticator, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case AuthenticatorDatabase:
+			authenticators = append(authenticators, &databaseAuthenticator{auth: s})
+		case AuthenticatorLDAP:
+			ldap, err := NewLDAPAuthenticator(db, cfg.LDAP, publisher)
+			if err != nil {
+				return nil, err
+			}
+			authenticators = append(authenticators, ldap)
+		default:
+			return nil, fmt.Errorf("unknown authenticator %q", name)
+		}
+	}
+	return authenticators, nil
+}
+
+// databaseAuthenticator는 DB에 저장된 비밀번호 해시로 로컬 사용자를 확인합니다
+type databaseAuthenticator struct {
+	auth *AuthService
+}
+
+func (a *databaseAuthenticator) Name() string {
+	return AuthenticatorDatabase
+}
+
+func (a *databaseAuthenticator) Authenticate(found *models.User, username, password string) (*models.User, error) {
+	if found == nil || found.PasswordHash == "" {
+		// 응답 시간으로 존재 여부가 드러나지 않도록 비밀번호 비교 비용을 똑같이 치름
+		a.auth.passwords.Verify(password, a.auth.dummyPasswordHash())
+		return nil, errors.ErrInvalidCredentials
+	}
+	if !a.auth.checkPassword(found, password) {
+		return nil, errors.ErrInvalidCredentials
+	}
+	return found, nil
+}
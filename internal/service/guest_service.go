@@ -0,0 +1,237 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+)
+
+// guestEmailDomain은 게스트 사용자에게 부여하는 예약된 도메인입니다 (메일이 전달되지 않음)
+const guestEmailDomain = "guests.invalid"
+
+// GuestService는 채팅방 공유 링크와, 링크로 들어온 게스트 계정을 관리합니다
+type GuestService struct {
+	db          *gorm.DB
+	authService *AuthService
+	publisher   events.Publisher
+}
+
+// NewGuestService는 새 GuestService 인스턴스를 생성합니다
+func NewGuestService(db *gorm.DB, authService *AuthService, publisher events.Publisher) *GuestService {
+	return &GuestService{db: db, authService: authService, publisher: publisher}
+}
+
+// CreateShareLink는 채팅방 관리자가 게스트 공유 링크를 만듭니다. 링크 토큰 원문은 응답에만 담기고 저장되지 않습니다
+func (s *GuestService) CreateShareLink(roomID, userID uint, req dto.CreateShareLinkRequest) (*dto.CreatedShareLinkResponse, error) {
+	if _, err := requireRoomRole(s.db, roomID, userID, roomRoleShareLinks); err != nil {
+		return nil, err
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	link := models.RoomShareLink{
+		RoomID:    roomID,
+		CreatedBy: userID,
+		TokenHash: hashToken(token),
+		CanWrite:  req.CanWrite,
+		ExpiresAt: time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour),
+	}
+	if err := s.db.Create(&link).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	return &dto.CreatedShareLinkResponse{ShareLinkResponse: toShareLinkResponse(&link), Token: token}, nil
+}
+
+// ListShareLinks는 채팅방의 공유 링크 목록을 반환합니다 (폐기되거나 만료된 링크 포함)
+func (s *GuestService) ListShareLinks(roomID, userID uint) ([]dto.ShareLinkResponse, error) {
+	if _, err := requireRoomRole(s.db, roomID, userID, roomRoleShareLinks); err != nil {
+		return nil, err
+	}
+
+	var links []models.RoomShareLink
+	if err := s.db.Where("room_id = ?", roomID).Order("id").Find(&links).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	resp := make([]dto.ShareLinkResponse, 0, len(links))
+	for i := range links {
+		resp = append(resp, toShareLinkResponse(&links[i]))
+	}
+	return resp, nil
+}
+
+// RevokeShareLink는 공유 링크를 폐기합니다. 이미 들어온 게스트는 계정이 만료될 때까지 남습니다
+func (s *GuestService) RevokeShareLink(roomID, userID, linkID uint) error {
+	if _, err := requireRoomRole(s.db, roomID, userID, roomRoleShareLinks); err != nil {
+		return err
+	}
+
+	result := s.db.Model(&models.RoomShareLink{}).
+		Where("id = ? AND room_id = ? AND revoked_at IS NULL", linkID, roomID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return errors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrShareLinkNotFound
+	}
+	return nil
+}
+
+// JoinAsGuest는 공유 링크를 확인하고 게스트 계정을 만들어 채팅방에 참여시킨 뒤 게스트 토큰을 발급합니다.
+// 게스트 계정은 guest_duration이 지나거나 링크가 만료되면(더 이른 쪽) 만료됩니다
+func (s *GuestService) JoinAsGuest(req dto.GuestJoinRequest, client ClientInfo) (*dto.GuestJoinResponse, error) {
+	s.PurgeExpiredGuests()
+
+	displayName := strings.TrimSpace(req.DisplayName)
+	if len(displayName) < 2 {
+		return nil, errors.ErrInvalidRequest
+	}
+
+	var link models.RoomShareLink
+	now := time.Now()
+	if err := s.db.Where("token_hash = ? AND revoked_at IS NULL AND expires_at > ?", hashToken(req.Token), now).
+		First(&link).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrShareLinkNotFound
+		}
+		return nil, errors.ErrDatabaseError
+	}
+
+	// 삭제된 채팅방의 링크는 사용할 수 없음
+	var room models.Room
+	if err := s.db.First(&room, link.RoomID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrShareLinkNotFound
+		}
+		return nil, errors.ErrDatabaseError
+	}
+	if room.ArchivedAt != nil {
+		return nil, errors.ErrRoomArchived
+	}
+
+	expiresAt := now.Add(s.authService.guestDuration)
+	if link.ExpiresAt.Before(expiresAt) {
+		expiresAt = link.ExpiresAt
+	}
+
+	// 같은 표시 이름의 게스트가 여럿 있을 수 있으므로 사용자 이름에 임의의 태그를 붙임
+	tag := make([]byte, 3)
+	if _, err := rand.Read(tag); err != nil {
+		return nil, err
+	}
+	emailLocal, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := []string{models.ScopeRoomsRead}
+	role := models.RoomRoleReadOnly
+	if link.CanWrite {
+		scopes = append(scopes, models.ScopeMessagesWrite)
+		role = models.RoomRoleMember
+	}
+
+	guest := models.User{
+		Username:      displayName + "#" + hex.EncodeToString(tag),
+		Email:         strings.ToLower(emailLocal) + "@" + guestEmailDomain,
+		IsGuest:       true,
+		ExpiresAt:     &expiresAt,
+		GuestRoomRole: role,
+	}
+	var token string
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&guest).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+
+		member := models.RoomUser{
+			RoomID:   room.ID,
+			UserID:   guest.ID,
+			JoinedAt: now,
+			Role:     role,
+		}
+		if err := tx.Create(&member).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+
+		var err error
+		token, err = s.authService.startGuestSession(tx, guest.ID, room.ID, scopes, expiresAt, client)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events.PublishOrLog(s.publisher, events.New(events.TypeRoomMemberJoined, 1, events.RoomKey(room.ID), events.RoomMemberJoined{
+		RoomID: room.ID,
+		UserID: guest.ID,
+		Guest:  true,
+	}))
+
+	return &dto.GuestJoinResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		UserID:    guest.ID,
+		Username:  guest.Username,
+		RoomID:    room.ID,
+		CanWrite:  link.CanWrite,
+	}, nil
+}
+
+// PurgeExpiredGuests는 만료된 게스트 계정을 삭제하고 채팅방에서 내보냅니다.
+// 게스트의 토큰은 계정과 같은 시각에 만료되므로 따로 폐기하지 않습니다
+func (s *GuestService) PurgeExpiredGuests() {
+	var guests []models.User
+	if err := s.db.Select("id").Where("is_guest = ? AND expires_at < ?", true, time.Now()).Find(&guests).Error; err != nil {
+		log.Printf("Failed to find expired guests: %v", err)
+		return
+	}
+
+	for _, guest := range guests {
+		var memberships []models.RoomUser
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("user_id = ?", guest.ID).Find(&memberships).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("user_id = ?", guest.ID).Delete(&models.RoomUser{}).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&guest).Error
+		})
+		if err != nil {
+			log.Printf("Failed to purge expired guest %d: %v", guest.ID, err)
+			continue
+		}
+
+		for _, m := range memberships {
+			events.PublishOrLog(s.publisher, events.New(events.TypeRoomMemberLeft, 1, events.RoomKey(m.RoomID), events.RoomMemberLeft{
+				RoomID: m.RoomID,
+				UserID: guest.ID,
+			}))
+		}
+	}
+}
+
+func toShareLinkResponse(link *models.RoomShareLink) dto.ShareLinkResponse {
+	return dto.ShareLinkResponse{
+		ID:        link.ID,
+		RoomID:    link.RoomID,
+		CanWrite:  link.CanWrite,
+		ExpiresAt: link.ExpiresAt,
+		RevokedAt: link.RevokedAt,
+		CreatedAt: link.CreatedAt,
+	}
+}
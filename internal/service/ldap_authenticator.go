@@ -0,0 +1,194 @@
+package service
+
+import (
+	"crypto/tls"
+	errs "errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+)
+
+// ldapIdentityProvider는 LDAP 사용자를 로컬 사용자와 연결할 때 UserIdentity에 기록하는 공급자 이름입니다
+const ldapIdentityProvider = "ldap"
+
+// LDAPAuthenticator는 LDAP 디렉터리로 비밀번호를 확인합니다 (search-then-bind).
+// 처음 로그인한 디렉터리 사용자는 로컬 사용자를 만들어 연결하고, 그룹 규칙이 있으면 로그인할 때마다 역할을 맞춥니다
+type LDAPAuthenticator struct {
+	db        *gorm.DB
+	cfg       config.LDAPConfig
+	publisher events.Publisher
+	tls       *tls.Config
+}
+
+// NewLDAPAuthenticator는 새 LDAPAuthenticator를 생성합니다. 필수 설정이 없거나 역할 규칙이 잘못되면 에러를 반환합니다
+func NewLDAPAuthenticator(db *gorm.DB, cfg config.LDAPConfig, publisher events.Publisher) (*LDAPAuthenticator, error) {
+	if cfg.URL == "" || cfg.BaseDN == "" {
+		return nil, fmt.Errorf("ldap: url and base_dn are required")
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid url: %w", err)
+	}
+	for _, rule := range cfg.GroupRoles {
+		if rule.Group == "" || !models.ValidRole(rule.Role) {
+			return nil, fmt.Errorf("ldap: invalid group role mapping %q -> %q", rule.Group, rule.Role)
+		}
+	}
+
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = "(uid=%s)"
+	}
+	if cfg.UsernameAttribute == "" {
+		cfg.UsernameAttribute = "uid"
+	}
+	if cfg.EmailAttribute == "" {
+		cfg.EmailAttribute = "mail"
+	}
+	if cfg.GroupAttribute == "" {
+		cfg.GroupAttribute = "memberOf"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	return &LDAPAuthenticator{
+		db:        db,
+		cfg:       cfg,
+		publisher: publisher,
+		tls: &tls.Config{
+			ServerName:         u.Hostname(),
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		},
+	}, nil
+}
+
+func (a *LDAPAuthenticator) Name() string {
+	return AuthenticatorLDAP
+}
+
+// Authenticate는 서비스 계정으로 사용자 항목을 찾고, 그 DN과 입력한 비밀번호로 바인드해 확인합니다.
+// 디렉터리에 연결할 수 없으면 ErrAuthUnavailable을 반환합니다
+func (a *LDAPAuthenticator) Authenticate(found *models.User, username, password string) (*models.User, error) {
+	// 빈 비밀번호 바인드는 많은 서버에서 익명 바인드로 성공하므로 거부
+	if username == "" || password == "" {
+		return nil, errors.ErrInvalidCredentials
+	}
+
+	conn, err := a.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entry, err := a.findEntry(conn, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errors.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("%w: ldap bind: %v", errors.ErrAuthUnavailable, err)
+	}
+
+	user, err := resolveExternalUser(a.db, a.publisher, ldapIdentityProvider, strings.ToLower(entry.DN), oidcClaims{
+		Email:             entry.GetAttributeValue(a.cfg.EmailAttribute),
+		EmailVerified:     true,
+		PreferredUsername: entry.GetAttributeValue(a.cfg.UsernameAttribute),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if user.IsBot || user.IsGuest {
+		return nil, errors.ErrInvalidCredentials
+	}
+
+	if err := a.syncRole(user, entry.GetAttributeValues(a.cfg.GroupAttribute)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (a *LDAPAuthenticator) dial() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(a.cfg.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: a.cfg.Timeout}),
+		ldap.DialWithTLSConfig(a.tls))
+	if err != nil {
+		return nil, fmt.Errorf("%w: ldap dial: %v", errors.ErrAuthUnavailable, err)
+	}
+	conn.SetTimeout(a.cfg.Timeout)
+
+	if a.cfg.StartTLS {
+		if err := conn.StartTLS(a.tls); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w: ldap starttls: %v", errors.ErrAuthUnavailable, err)
+		}
+	}
+
+	if a.cfg.BindDN != "" {
+		if err := conn.Bind(a.cfg.BindDN, a.cfg.BindPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("%w: ldap service bind: %v", errors.ErrAuthUnavailable, err)
+		}
+	}
+	return conn, nil
+}
+
+// findEntry는 사용자 이름으로 디렉터리 항목을 찾습니다. 없거나 여러 개면 ErrInvalidCredentials를 반환합니다
+func (a *LDAPAuthenticator) findEntry(conn *ldap.Conn, username string) (*ldap.Entry, error) {
+	req := ldap.NewSearchRequest(
+		a.cfg.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, int(a.cfg.Timeout/time.Second), false,
+		fmt.Sprintf(a.cfg.UserFilter, ldap.EscapeFilter(username)),
+		[]string{a.cfg.UsernameAttribute, a.cfg.EmailAttribute, a.cfg.GroupAttribute},
+		nil,
+	)
+	result, err := conn.Search(req)
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		var ldapErr *ldap.Error
+		if errs.As(err, &ldapErr) && ldapErr.ResultCode == ldap.LDAPResultNoSuchObject {
+			return nil, errors.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("%w: ldap search: %v", errors.ErrAuthUnavailable, err)
+	}
+	if result == nil || len(result.Entries) != 1 {
+		return nil, errors.ErrInvalidCredentials
+	}
+	return result.Entries[0], nil
+}
+
+// syncRole은 사용자가 속한 그룹에 맞춰 시스템 역할을 갱신합니다. 역할 규칙이 없으면 역할을 바꾸지 않습니다
+func (a *LDAPAuthenticator) syncRole(user *models.User, groups []string) error {
+	if len(a.cfg.GroupRoles) == 0 {
+		return nil
+	}
+
+	role := models.RoleUser
+	for _, rule := range a.cfg.GroupRoles {
+		for _, group := range groups {
+			// DN은 대소문자를 구별하지 않음
+			if strings.EqualFold(group, rule.Group) && models.RoleAtLeast(rule.Role, role) {
+				role = rule.Role
+			}
+		}
+	}
+
+	if user.Role == role {
+		return nil
+	}
+	if err := a.db.Model(user).Update("role", role).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+// countedLoginFailures는 시도 횟수 제한에 포함되는 실패 결과입니다
+var countedLoginFailures = []string{models.LoginInvalidCredentials, models.LoginInvalidTwoFactor}
+
+// ClientInfo는 요청을 보낸 클라이언트 정보입니다
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type clientInfoKey struct{}
+
+// WithClientInfo는 요청 클라이언트 정보를 ctx에 담습니다
+func WithClientInfo(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, client)
+}
+
+// ClientInfoFrom은 WithClientInfo로 담은 클라이언트 정보를 반환합니다. 없으면 빈 값입니다
+func ClientInfoFrom(ctx context.Context) ClientInfo {
+	client, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return client
+}
+
+// LoginThrottle은 계정별, IP별 로그인 실패를 기록하고 지수적 대기 시간과 임시 잠금을 적용합니다.
+// 기록은 DB에 남으므로 여러 인스턴스가 같은 제한을 공유합니다
+type LoginThrottle struct {
+	db  *gorm.DB
+	cfg config.LoginThrottleConfig
+}
+
+// NewLoginThrottle은 새 LoginThrottle을 생성합니다. 설정되지 않은 값은 기본값을 사용합니다
+func NewLoginThrottle(db *gorm.DB, cfg config.LoginThrottleConfig) *LoginThrottle {
+	if cfg.Window <= 0 {
+		cfg.Window = 15 * time.Minute
+	}
+	if cfg.FreeAttempts <= 0 {
+		cfg.FreeAttempts = 3
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = time.Second
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = time.Minute
+	}
+	if cfg.MaxAccountFailures <= 0 {
+		cfg.MaxAccountFailures = 10
+	}
+	if cfg.LockoutDuration <= 0 {
+		cfg.LockoutDuration = 15 * time.Minute
+	}
+	if cfg.MaxIPFailures <= 0 {
+		cfg.MaxIPFailures = 100
+	}
+	return &LoginThrottle{db: db, cfg: cfg}
+}
+
+// accountKey는 시도 횟수를 셀 계정 키를 만듭니다.
+// 사용자 이름과 이메일 중 무엇으로 시도해도 같은 계정으로 세고, 없는 이름도 같은 방식으로 제한해 존재 여부가 드러나지 않게 합니다
+func accountKey(user *models.User, identifier string) string {
+	if user != nil {
+		return fmt.Sprintf("user:%d", user.ID)
+	}
+	return "name:" + strings.ToLower(strings.TrimSpace(identifier))
+}
+
+// Check는 계정과 IP가 지금 로그인을 시도할 수 있는지 확인합니다. 제한에 걸리면 *errors.ThrottledError를 반환합니다
+func (t *LoginThrottle) Check(key, ip string) error {
+	now := time.Now()
+	var retryAt time.Time
+
+	// 계정: 마지막 성공 이후의 연속 실패만 셈
+	since := now.Add(-t.cfg.Window)
+	var lastSuccess []time.Time
+	if err := t.db.Model(&models.LoginAttempt{}).
+		Where("account_key = ? AND success = ?", key, true).
+		Order("created_at DESC").Limit(1).
+		Pluck("created_at", &lastSuccess).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+	if len(lastSuccess) > 0 && lastSuccess[0].After(since) {
+		since = lastSuccess[0]
+	}
+
+	var failures []time.Time
+	if err := t.db.Model(&models.LoginAttempt{}).
+		Where("account_key = ? AND result IN ? AND created_at > ?", key, countedLoginFailures, since).
+		Order("created_at DESC").Limit(t.cfg.MaxAccountFailures).
+		Pluck("created_at", &failures).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+
+	if n := len(failures); n >= t.cfg.MaxAccountFailures {
+		retryAt = failures[0].Add(t.cfg.LockoutDuration)
+	} else if n >= t.cfg.FreeAttempts {
+		retryAt = failures[0].Add(t.backoff(n - t.cfg.FreeAttempts))
+	}
+
+	// IP: 창 안의 실패 수가 한도에 이르면 가장 오래된 실패가 창을 벗어날 때까지 막음
+	if ip != "" {
+		var ipFailures []time.Time
+		if err := t.db.Model(&models.LoginAttempt{}).
+			Where("ip = ? AND result IN ? AND created_at > ?", ip, countedLoginFailures, now.Add(-t.cfg.Window)).
+			Order("created_at DESC").Limit(t.cfg.MaxIPFailures).
+			Pluck("created_at", &ipFailures).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if len(ipFailures) >= t.cfg.MaxIPFailures {
+			if ipRetryAt := ipFailures[len(ipFailures)-1].Add(t.cfg.Window); ipRetryAt.After(retryAt) {
+				retryAt = ipRetryAt
+			}
+		}
+	}
+
+	if retryAt.After(now) {
+		return &errors.ThrottledError{RetryAfter: retryAt.Sub(now)}
+	}
+	return nil
+}
+
+// backoff는 무료 시도 이후 n번째 실패에 적용할 대기 시간입니다
+func (t *LoginThrottle) backoff(n int) time.Duration {
+	delay := t.cfg.BaseDelay
+	for i := 0; i < n && delay < t.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > t.cfg.MaxDelay {
+		delay = t.cfg.MaxDelay
+	}
+	return delay
+}
+
+// Record는 로그인 시도를 기록합니다. 기록에 실패해도 로그인 자체는 막지 않습니다
+func (t *LoginThrottle) Record(key, identifier string, user *models.User, client ClientInfo, result string) {
+	attempt := models.LoginAttempt{
+		AccountKey: key,
+		Identifier: identifier,
+		IP:         client.IP,
+		UserAgent:  truncate(client.UserAgent, 512),
+		Success:    result == models.LoginSucceeded,
+		Result:     result,
+	}
+	if user != nil {
+		attempt.UserID = &user.ID
+	}
+
+	if err := t.db.Create(&attempt).Error; err != nil {
+		log.Printf("Failed to record login attempt: %v", err)
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
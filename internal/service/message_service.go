@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"mult-working/internal/dto"
 	"mult-working/internal/errors"
+	"mult-working/internal/events"
 	"mult-working/internal/models"
 	"time"
 
@@ -13,13 +14,15 @@ import (
 
 // MessageService는 메시지 관련 기능을 제공합니다
 type MessageService struct {
-	db *gorm.DB
+	db        *gorm.DB
+	publisher events.Publisher
 }
 
 // NewMessageService는 새로운 MessageService 인스턴스를 생성합니다
-func NewMessageService(db *gorm.DB) *MessageService {
+func NewMessageService(db *gorm.DB, publisher events.Publisher) *MessageService {
 	return &MessageService{
-		db: db,
+		db:        db,
+		publisher: publisher,
 	}
 }
 
@@ -34,14 +37,21 @@ func (s *MessageService) CreateMessage(req dto.CreateMessageRequest, userID uint
 		return nil, errors.ErrDatabaseError
 	}
 
-	// 사용자가 채팅방에 참여 중인지 확인
-	var count int64
-	if err := s.db.Model(&models.RoomUser{}).Where("room_id = ? AND user_id = ?", req.RoomID, userID).Count(&count).Error; err != nil {
-		return nil, errors.ErrDatabaseError
+	// 보관된 채팅방은 읽기 전용
+	if room.ArchivedAt != nil {
+		return nil, errors.ErrRoomArchived
 	}
 
-	if count == 0 {
-		return nil, errors.ErrNotJoined
+	// 사용자가 채팅방에 참여 중인지, 쓰기 권한이 있는지 확인
+	member, err := findRoomMember(s.db, req.RoomID, userID)
+	if err != nil {
+		if err == errors.ErrMemberNotFound {
+			return nil, errors.ErrNotJoined
+		}
+		return nil, err
+	}
+	if !models.RoomRoleAtLeast(member.Role, roomRolePost) {
+		return nil, errors.ErrPermissionDenied
 	}
 
 	// 메시지 생성
@@ -55,9 +65,16 @@ func (s *MessageService) CreateMessage(req dto.CreateMessageRequest, userID uint
 		return nil, errors.ErrDatabaseError
 	}
 
+	events.PublishOrLog(s.publisher, events.New(events.TypeMessageCreated, 1, events.RoomKey(message.RoomID), events.MessageCreated{
+		MessageID: message.ID,
+		RoomID:    message.RoomID,
+		UserID:    message.UserID,
+		Content:   message.Content,
+	}))
+
 	// 사용자 정보 조회
 	var user models.User
-	if err := s.db.Select("username").First(&user, userID).Error; err != nil {
+	if err := s.db.Select("username", "is_guest").First(&user, userID).Error; err != nil {
 		return nil, errors.ErrDatabaseError
 	}
 
@@ -66,6 +83,7 @@ func (s *MessageService) CreateMessage(req dto.CreateMessageRequest, userID uint
 		Content:   message.Content,
 		UserID:    message.UserID,
 		Username:  user.Username,
+		IsGuest:   user.IsGuest,
 		RoomID:    message.RoomID,
 		CreatedAt: message.CreatedAt,
 	}, nil
@@ -98,14 +116,15 @@ func (s *MessageService) GetRoomMessages(roomID, userID uint) ([]dto.MessageResp
 		Content   string    `json:"content"`
 		UserID    uint      `json:"userId"`
 		Username  string    `json:"username"`
+		IsGuest   bool      `json:"isGuest,omitempty"`
 		RoomID    uint      `json:"roomId"`
 		CreatedAt time.Time `json:"createdAt"`
 	}
 
 	if err := s.db.Table("messages").
-		Select("messages.id, messages.content, messages.user_id, users.username, messages.room_id, messages.created_at").
+		Select("messages.id, messages.content, messages.user_id, users.username, users.is_guest, messages.room_id, messages.created_at").
 		Joins("left join users on messages.user_id = users.id").
-		Where("messages.room_id = ?", roomID).
+		Where("messages.room_id = ? AND messages.deleted_at IS NULL", roomID).
 		Order("messages.created_at desc").
 		Limit(100).
 		Find(&messages).Error; err != nil {
@@ -119,6 +138,7 @@ func (s *MessageService) GetRoomMessages(roomID, userID uint) ([]dto.MessageResp
 			Content:   m.Content,
 			UserID:    m.UserID,
 			Username:  m.Username,
+			IsGuest:   m.IsGuest,
 			RoomID:    m.RoomID,
 			CreatedAt: m.CreatedAt,
 		})
@@ -127,17 +147,86 @@ func (s *MessageService) GetRoomMessages(roomID, userID uint) ([]dto.MessageResp
 	return response, nil
 }
 
+// DeleteMessage는 메시지를 삭제합니다. 자기 메시지는 멤버라면 누구나, 다른 사람의 메시지는 모더레이터 이상만 삭제할 수 있습니다
+func (s *MessageService) DeleteMessage(messageID, userID uint) error {
+	var message models.Message
+	if err := s.db.First(&message, messageID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrMessageNotFound
+		}
+		return errors.ErrDatabaseError
+	}
+
+	// 보관된 채팅방은 읽기 전용
+	var room models.Room
+	if err := s.db.First(&room, message.RoomID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrRoomNotFound
+		}
+		return errors.ErrDatabaseError
+	}
+	if room.ArchivedAt != nil {
+		return errors.ErrRoomArchived
+	}
+
+	required := models.RoomRoleReadOnly
+	if message.UserID != userID {
+		required = roomRoleDeleteMessages
+	}
+	if _, err := requireRoomRole(s.db, message.RoomID, userID, required); err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(&message).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+	return nil
+}
+
+// CanSubscribe는 사용자가 웹소켓으로 채팅방 메시지를 받을 수 있는지 확인합니다.
+// 공개 채팅방은 차단되지 않은 누구나, 그 밖의 채팅방은 멤버만 받을 수 있습니다
+func (s *MessageService) CanSubscribe(roomID, userID uint) error {
+	var room models.Room
+	if err := s.db.First(&room, roomID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrRoomNotFound
+		}
+		return errors.ErrDatabaseError
+	}
+	if room.Visibility == models.RoomPublic {
+		banned, err := isBanned(s.db, roomID, userID)
+		if err != nil {
+			return err
+		}
+		if banned {
+			return errors.ErrBanned
+		}
+		return nil
+	}
+
+	joined, err := isRoomMember(s.db, roomID, userID)
+	if err != nil {
+		return err
+	}
+	if !joined {
+		return errors.ErrNotJoined
+	}
+	return nil
+}
+
 // GetDB는 서비스의 DB 인스턴스를 반환합니다
 func (s *MessageService) GetDB() *gorm.DB {
 	return s.db
 }
 
-// GetMessagesByRoomId는 채팅방 ID를 기준으로 메시지를 페이지네이션하여 조회합니다
-func (s *MessageService) GetMessagesByRoomId(id string, limit int, offset int, messages *[]struct {
+// GetMessagesByRoomId는 채팅방 ID를 기준으로 메시지를 페이지네이션하여 조회합니다.
+// 공개 채팅방이 아니면 멤버만 조회할 수 있습니다
+func (s *MessageService) GetMessagesByRoomId(id string, userID uint, limit int, offset int, messages *[]struct {
 	ID        uint      `json:"id"`
 	Content   string    `json:"content"`
 	UserID    uint      `json:"userId"`
 	Username  string    `json:"username"`
+	IsGuest   bool      `json:"isGuest,omitempty"`
 	RoomID    uint      `json:"roomId"`
 	CreatedAt time.Time `json:"createdAt"`
 }) error {
@@ -146,20 +235,19 @@ func (s *MessageService) GetMessagesByRoomId(id string, limit int, offset int, m
 		return errs.New("invalid room ID")
 	}
 
-	// 채팅방 존재 여부 확인
-	var room models.Room
-	if err := s.db.First(&room, roomID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return errors.ErrRoomNotFound
+	// 채팅방 존재 여부와 읽기 권한 확인
+	if err := s.CanSubscribe(roomID, userID); err != nil {
+		if err == errors.ErrNotJoined {
+			return errors.ErrPermissionDenied
 		}
-		return errors.ErrDatabaseError
+		return err
 	}
 
 	// 메시지 조회 (최신순으로 정렬) - 사용자 이름 포함
 	if err := s.db.Table("messages").
-		Select("messages.id, messages.content, messages.user_id, users.username, messages.room_id, messages.created_at").
+		Select("messages.id, messages.content, messages.user_id, users.username, users.is_guest, messages.room_id, messages.created_at").
 		Joins("LEFT JOIN users ON messages.user_id = users.id").
-		Where("messages.room_id = ?", roomID).
+		Where("messages.room_id = ? AND messages.deleted_at IS NULL", roomID).
 		Order("messages.created_at DESC").
 		Limit(limit).
 		Offset(offset).
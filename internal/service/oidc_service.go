@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	errs "errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+)
+
+// usernameInvalidChars는 공급자가 준 이름에서 사용자 이름에 쓰지 않을 문자입니다
+var usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// oidcClaims는 ID 토큰에서 사용하는 클레임입니다
+type oidcClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// oidcProvider는 설정된 공급자 하나입니다. 디스커버리는 처음 사용할 때 수행하고 성공하면 캐시합니다
+type oidcProvider struct {
+	cfg config.OIDCProviderConfig
+
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, p.cfg.IssuerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc discovery for %q failed: %w", p.cfg.Name, err)
+	}
+
+	scopes := []string{oidc.ScopeOpenID}
+	for _, scope := range p.cfg.Scopes {
+		if scope != oidc.ScopeOpenID {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       scopes,
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+	return p.oauth, p.verifier, nil
+}
+
+// OIDCService는 OpenID Connect 공급자를 통한 로그인(authorization code + PKCE)을 제공합니다
+type OIDCService struct {
+	db          *gorm.DB
+	authService *AuthService
+	publisher   events.Publisher
+	stateTTL    time.Duration
+	providers   map[string]*oidcProvider
+}
+
+// NewOIDCService는 공급자 설정을 검증하고 새 OIDCService를 생성합니다
+func NewOIDCService(db *gorm.DB, cfg config.OIDCConfig, authService *AuthService, publisher events.Publisher) (*OIDCService, error) {
+	var problems []error
+	providers := make(map[string]*oidcProvider)
+	for i, p := range cfg.Providers {
+		switch {
+		case p.Name == "":
+			problems = append(problems, fmt.Errorf("auth.oidc.providers[%d].name: required", i))
+		case providers[p.Name] != nil:
+			problems = append(problems, fmt.Errorf("auth.oidc.providers[%d].name: duplicate provider %q", i, p.Name))
+		}
+		if p.IssuerURL == "" {
+			problems = append(problems, fmt.Errorf("auth.oidc.providers[%d].issuer_url: required", i))
+		}
+		if p.ClientID == "" {
+			problems = append(problems, fmt.Errorf("auth.oidc.providers[%d].client_id: required", i))
+		}
+		if p.RedirectURL == "" {
+			problems = append(problems, fmt.Errorf("auth.oidc.providers[%d].redirect_url: required", i))
+		}
+		providers[p.Name] = &oidcProvider{cfg: p}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid oidc configuration: %w", errs.Join(problems...))
+	}
+
+	stateTTL := cfg.StateTTL
+	if stateTTL <= 0 {
+		stateTTL = 10 * time.Minute
+	}
+
+	return &OIDCService{
+		db:          db,
+		authService: authService,
+		publisher:   publisher,
+		stateTTL:    stateTTL,
+		providers:   providers,
+	}, nil
+}
+
+// ProviderNames는 설정된 공급자 이름 목록을 반환합니다
+func (s *OIDCService) ProviderNames() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AuthCodeURL은 로그인 상태(state, nonce, PKCE verifier)를 저장하고 공급자의 인증 URL을 반환합니다
+func (s *OIDCService) AuthCodeURL(ctx context.Context, providerName string) (string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", errors.ErrProviderNotFound
+	}
+
+	oauth, _, err := provider.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	loginState := models.OIDCLoginState{
+		StateHash:    hashToken(state),
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(s.stateTTL),
+	}
+	if err := s.db.Create(&loginState).Error; err != nil {
+		return "", errors.ErrDatabaseError
+	}
+
+	// 완료되지 않은 로그인 상태 정리
+	if err := s.db.Where("expires_at < ?", time.Now()).Delete(&models.OIDCLoginState{}).Error; err != nil {
+		log.Printf("Failed to purge expired oidc login states: %v", err)
+	}
+
+	return oauth.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Callback은 인증 코드를 토큰으로 교환하고 ID 토큰을 검증한 뒤, 연결된 로컬 사용자로 로그인합니다.
+// 연결된 사용자가 없으면 확인된 이메일로 기존 사용자에 연결하거나 새 사용자를 만듭니다.
+// 세션에 기록할 클라이언트 정보는 WithClientInfo로 ctx에 담아 전달합니다
+func (s *OIDCService) Callback(ctx context.Context, providerName, state, code string) (*dto.TokenResponse, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, errors.ErrProviderNotFound
+	}
+
+	loginState, err := s.consumeState(providerName, state)
+	if err != nil {
+		return nil, err
+	}
+
+	oauth, verifier, err := provider.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauth.Exchange(ctx, code, oauth2.VerifierOption(loginState.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: code exchange failed: %v", errors.ErrInvalidCredentials, err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: no id_token in token response", errors.ErrInvalidToken)
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidToken, err)
+	}
+	if idToken.Nonce != loginState.Nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", errors.ErrInvalidToken)
+	}
+
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidToken, err)
+	}
+
+	user, err := resolveExternalUser(s.db, s.publisher, providerName, idToken.Subject, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.StartSession(user.ID, ClientInfoFrom(ctx))
+}
+
+// consumeState는 로그인 상태를 조회하고 삭제합니다. 같은 state는 한 번만 사용할 수 있습니다
+func (s *OIDCService) consumeState(providerName, state string) (*models.OIDCLoginState, error) {
+	if state == "" {
+		return nil, errors.ErrInvalidLoginState
+	}
+
+	var loginState models.OIDCLoginState
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state_hash = ? AND provider = ?", hashToken(state), providerName).First(&loginState).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrInvalidLoginState
+			}
+			return errors.ErrDatabaseError
+		}
+
+		result := tx.Where("state_hash = ?", loginState.StateHash).Delete(&models.OIDCLoginState{})
+		if result.Error != nil {
+			return errors.ErrDatabaseError
+		}
+		if result.RowsAffected == 0 {
+			// 동시에 들어온 다른 콜백이 먼저 사용함
+			return errors.ErrInvalidLoginState
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(loginState.ExpiresAt) {
+		return nil, errors.ErrInvalidLoginState
+	}
+	return &loginState, nil
+}
+
+// resolveExternalUser는 외부 계정(OIDC 공급자, LDAP 디렉터리)에 연결된 로컬 사용자를 찾고, 없으면 연결하거나 새로 만듭니다
+func resolveExternalUser(db *gorm.DB, publisher events.Publisher, providerName, subject string, claims oidcClaims) (*models.User, error) {
+	var user models.User
+	var created bool
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var identity models.UserIdentity
+		err := tx.Where("provider = ? AND subject = ?", providerName, subject).First(&identity).Error
+		if err == nil {
+			if err := tx.First(&user, identity.UserID).Error; err != nil {
+				return errors.ErrUserNotFound
+			}
+			if claims.EmailVerified && claims.Email != "" && identity.Email != claims.Email {
+				if err := tx.Model(&identity).Update("email", claims.Email).Error; err != nil {
+					return errors.ErrDatabaseError
+				}
+			}
+			return nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return errors.ErrDatabaseError
+		}
+
+		// 처음 보는 계정은 공급자가 확인한 이메일이 있어야 연결하거나 만들 수 있음
+		if claims.Email == "" || !claims.EmailVerified {
+			return errors.ErrEmailNotVerified
+		}
+
+		err = tx.Where("LOWER(email) = ?", strings.ToLower(claims.Email)).First(&user).Error
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			user, err = provisionUser(tx, claims)
+			if err != nil {
+				return err
+			}
+			created = true
+		case err != nil:
+			return errors.ErrDatabaseError
+		case user.EmailVerifiedAt == nil:
+			// 공급자가 주소를 확인했으므로 로컬 계정도 인증된 것으로 처리
+			now := time.Now()
+			if err := tx.Model(&user).Update("email_verified_at", now).Error; err != nil {
+				return errors.ErrDatabaseError
+			}
+		}
+
+		identity = models.UserIdentity{
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  subject,
+			Email:    claims.Email,
+		}
+		if err := tx.Create(&identity).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		events.PublishOrLog(publisher, events.New(events.TypeUserRegistered, 1, events.UserKey(user.ID), events.UserRegistered{
+			UserID:   user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+		}))
+	}
+
+	return &user, nil
+}
+
+// provisionUser는 공급자 계정으로 새 사용자를 만듭니다. 비밀번호가 없으므로 비밀번호로는 로그인할 수 없습니다
+func provisionUser(tx *gorm.DB, claims oidcClaims) (models.User, error) {
+	base := usernameInvalidChars.ReplaceAllString(claims.PreferredUsername, "")
+	if base == "" {
+		base = usernameInvalidChars.ReplaceAllString(strings.SplitN(claims.Email, "@", 2)[0], "")
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	username := base
+	for attempt := 0; ; attempt++ {
+		var count int64
+		if err := tx.Model(&models.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+			return models.User{}, errors.ErrDatabaseError
+		}
+		if count == 0 {
+			break
+		}
+		if attempt >= 5 {
+			return models.User{}, errors.ErrUserExists
+		}
+		suffix, err := randomToken(3)
+		if err != nil {
+			return models.User{}, err
+		}
+		username = base + "-" + strings.ToLower(usernameInvalidChars.ReplaceAllString(suffix, ""))
+	}
+
+	now := time.Now()
+	user := models.User{
+		Username:        username,
+		Email:           claims.Email,
+		EmailVerifiedAt: &now,
+	}
+	if err := tx.Create(&user).Error; err != nil {
+		return models.User{}, errors.ErrDatabaseError
+	}
+	return user, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"mult-working/internal/config"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+const (
+	// PresenceHeartbeatInterval은 인스턴스가 연결된 사용자의 접속 상태를 갱신하는 주기입니다
+	PresenceHeartbeatInterval = 30 * time.Second
+	// presenceTTL이 지나도록 갱신되지 않은 접속 상태는 오프라인으로 봅니다
+	presenceTTL = 3 * PresenceHeartbeatInterval
+)
+
+// PresenceStore는 여러 서버 인스턴스에 걸친 사용자 접속 상태를 DB에 저장합니다
+type PresenceStore struct {
+	db *gorm.DB
+}
+
+// NewPresenceStore는 새 PresenceStore를 생성합니다
+func NewPresenceStore(db *gorm.DB) *PresenceStore {
+	return &PresenceStore{db: db}
+}
+
+// Connected는 이 인스턴스에 사용자의 첫 소켓이 연결되었음을 기록합니다
+func (p *PresenceStore) Connected(userID uint) error {
+	presence := models.Presence{UserID: userID, InstanceID: config.ServerInstanceID, LastSeenAt: time.Now()}
+	return p.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "instance_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_seen_at"}),
+	}).Create(&presence).Error
+}
+
+// Disconnected는 이 인스턴스에서 사용자의 마지막 소켓이 끊겼음을 기록합니다
+func (p *PresenceStore) Disconnected(userID uint) error {
+	return p.db.Where("user_id = ? AND instance_id = ?", userID, config.ServerInstanceID).Delete(&models.Presence{}).Error
+}
+
+// Heartbeat는 이 인스턴스에 연결된 사용자들의 접속 상태를 갱신합니다
+func (p *PresenceStore) Heartbeat(userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return p.db.Model(&models.Presence{}).
+		Where("instance_id = ? AND user_id IN ?", config.ServerInstanceID, userIDs).
+		Update("last_seen_at", time.Now()).Error
+}
+
+// Clear는 이 인스턴스의 접속 상태를 모두 지웁니다 (종료 시)
+func (p *PresenceStore) Clear() error {
+	return p.db.Where("instance_id = ?", config.ServerInstanceID).Delete(&models.Presence{}).Error
+}
+
+// Online은 주어진 사용자 중 어느 인스턴스에든 접속해 있는 사용자를 반환합니다
+func (p *PresenceStore) Online(userIDs []uint) (map[uint]bool, error) {
+	online := make(map[uint]bool)
+	if len(userIDs) == 0 {
+		return online, nil
+	}
+
+	var ids []uint
+	if err := p.db.Model(&models.Presence{}).
+		Where("user_id IN ? AND last_seen_at > ?", userIDs, time.Now().Add(-presenceTTL)).
+		Distinct().Pluck("user_id", &ids).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+	for _, id := range ids {
+		online[id] = true
+	}
+	return online, nil
+}
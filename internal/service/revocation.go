@@ -0,0 +1,204 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Revocation은 폐기된 토큰 정보입니다. SessionID가 있으면 그 세션의 토큰이,
+// TokenID와 SessionID가 모두 비어 있으면 사용자의 모든 토큰이 폐기된 것입니다
+type Revocation struct {
+	UserID    uint   `json:"userId"`
+	TokenID   string `json:"tokenId,omitempty"`
+	SessionID string `json:"sessionId,omitempty"`
+}
+
+// RevocationListener는 토큰이 폐기될 때 호출됩니다 (예: 다른 인스턴스에 전파, 소켓 종료)
+type RevocationListener func(Revocation)
+
+const (
+	// revocationCacheTTL 동안은 같은 토큰의 폐기 여부를 DB에 다시 묻지 않습니다.
+	// 이 인스턴스에서 폐기하거나 다른 인스턴스의 폐기 알림을 받으면 바로 비웁니다
+	revocationCacheTTL = 5 * time.Second
+	// revocationCacheLimit를 넘으면 만료된 캐시 항목을 정리합니다
+	revocationCacheLimit = 10000
+)
+
+type revocationCacheEntry struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+// RevocationStore는 폐기된 토큰과 사용자별 전체 폐기 시각을 저장합니다
+type RevocationStore struct {
+	db *gorm.DB
+
+	mu        sync.RWMutex
+	listeners []RevocationListener
+
+	cacheMu sync.Mutex
+	cache   map[string]revocationCacheEntry
+}
+
+// NewRevocationStore는 새 RevocationStore를 생성합니다
+func NewRevocationStore(db *gorm.DB) *RevocationStore {
+	return &RevocationStore{
+		db:    db,
+		cache: make(map[string]revocationCacheEntry),
+	}
+}
+
+// OnRevoke는 폐기 시 호출될 리스너를 등록합니다
+func (r *RevocationStore) OnRevoke(listener RevocationListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, listener)
+}
+
+// RevokeToken은 단일 토큰을 만료 시각까지 폐기합니다
+func (r *RevocationStore) RevokeToken(claims *TokenClaims) error {
+	revoked := models.RevokedToken{
+		JTI:       claims.TokenID,
+		UserID:    claims.UserID,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+		RevokedAt: time.Now(),
+	}
+	if err := r.db.Save(&revoked).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+
+	// 만료된 폐기 기록은 더 이상 필요 없으므로 정리
+	if err := r.db.Where("expires_at < ?", time.Now()).Delete(&models.RevokedToken{}).Error; err != nil {
+		log.Printf("Failed to purge expired revoked tokens: %v", err)
+	}
+
+	r.notify(Revocation{UserID: claims.UserID, TokenID: claims.TokenID})
+	return nil
+}
+
+// RevokeUser는 사용자에게 지금까지 발급된 모든 토큰을 폐기합니다
+func (r *RevocationStore) RevokeUser(userID uint) error {
+	// DB에 따라 마이크로초까지만 저장되므로 올림해서 지금까지 발급된 토큰이 모두 이전이 되도록 함
+	now := time.Now().Truncate(time.Microsecond).Add(time.Microsecond)
+	result := r.db.Model(&models.User{}).Where("id = ?", userID).Update("tokens_revoked_at", now)
+	if result.Error != nil {
+		return errors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrUserNotFound
+	}
+
+	r.notify(Revocation{UserID: userID})
+	return nil
+}
+
+// RevokeSession은 사용자의 세션 하나를 폐기합니다. 세션에 속한 토큰은 IsRevoked에서 거부됩니다
+func (r *RevocationStore) RevokeSession(userID uint, sessionID string) error {
+	result := r.db.Model(&models.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return errors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrSessionNotFound
+	}
+
+	r.notify(Revocation{UserID: userID, SessionID: sessionID})
+	return nil
+}
+
+// IsRevoked는 토큰이 개별 폐기되었거나, 세션이 폐기되었거나, 사용자 전체 폐기 이전에 발급되었는지 확인합니다.
+// 결과는 revocationCacheTTL 동안 캐시합니다
+func (r *RevocationStore) IsRevoked(claims *TokenClaims) (bool, error) {
+	key := fmt.Sprintf("%d/%s/%s/%d", claims.UserID, claims.SessionID, claims.TokenID, claims.issuedAt().UnixNano())
+
+	now := time.Now()
+	r.cacheMu.Lock()
+	entry, ok := r.cache[key]
+	r.cacheMu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := r.lookupRevoked(claims)
+	if err != nil {
+		return false, err
+	}
+
+	r.cacheMu.Lock()
+	if len(r.cache) >= revocationCacheLimit {
+		for k, e := range r.cache {
+			if !now.Before(e.expiresAt) {
+				delete(r.cache, k)
+			}
+		}
+	}
+	r.cache[key] = revocationCacheEntry{revoked: revoked, expiresAt: now.Add(revocationCacheTTL)}
+	r.cacheMu.Unlock()
+
+	return revoked, nil
+}
+
+// Invalidate는 폐기 여부 캐시를 비웁니다. 다른 인스턴스에서 폐기 알림을 받았을 때 호출합니다
+func (r *RevocationStore) Invalidate() {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	r.cache = make(map[string]revocationCacheEntry)
+}
+
+// lookupRevoked는 DB에서 토큰의 폐기 여부를 확인합니다
+func (r *RevocationStore) lookupRevoked(claims *TokenClaims) (bool, error) {
+	if claims.SessionID != "" {
+		// 만료되어 정리된 세션도 폐기된 것으로 봄
+		var count int64
+		if err := r.db.Model(&models.Session{}).Where("id = ? AND revoked_at IS NULL", claims.SessionID).Count(&count).Error; err != nil {
+			return false, errors.ErrDatabaseError
+		}
+		if count == 0 {
+			return true, nil
+		}
+	}
+
+	if claims.TokenID != "" {
+		var count int64
+		if err := r.db.Model(&models.RevokedToken{}).Where("jti = ?", claims.TokenID).Count(&count).Error; err != nil {
+			return false, errors.ErrDatabaseError
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	var user models.User
+	if err := r.db.Select("id", "tokens_revoked_at").First(&user, claims.UserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return true, nil
+		}
+		return false, errors.ErrDatabaseError
+	}
+
+	if user.TokensRevokedAt != nil && claims.issuedAt().Before(*user.TokensRevokedAt) {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (r *RevocationStore) notify(rev Revocation) {
+	r.Invalidate()
+
+	r.mu.RLock()
+	listeners := append([]RevocationListener(nil), r.listeners...)
+	r.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(rev)
+	}
+}
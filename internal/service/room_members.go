@@ -0,0 +1,104 @@
+package service
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+// ListMembers는 채팅방 멤버를 사용자 이름순으로 커서 페이지네이션하여 반환합니다.
+// prefix가 있으면 사용자 이름이 그 접두어로 시작하는 멤버만 반환합니다 (대소문자 무시).
+// 공개 채팅방은 차단되지 않은 누구나, 그 밖의 채팅방은 멤버만 조회할 수 있습니다
+func (s *RoomService) ListMembers(roomID, userID uint, prefix, cursor string, limit int) (*dto.RoomMemberListResponse, error) {
+	room, err := s.loadRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.Visibility == models.RoomPublic {
+		banned, err := isBanned(s.db, roomID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if banned {
+			return nil, errors.ErrBanned
+		}
+	} else {
+		joined, err := isRoomMember(s.db, roomID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !joined {
+			// 비공개 채팅방은 존재 여부도 드러내지 않음
+			if room.Visibility == models.RoomPrivate {
+				return nil, errors.ErrRoomNotFound
+			}
+			return nil, errors.ErrPermissionDenied
+		}
+	}
+
+	query := s.db.Table("room_users").
+		Select("room_users.user_id, users.username, room_users.role, users.is_guest, room_users.joined_at").
+		Joins("JOIN users ON users.id = room_users.user_id AND users.deleted_at IS NULL").
+		Where("room_users.room_id = ?", roomID)
+
+	if prefix = strings.ToLower(strings.TrimSpace(prefix)); prefix != "" {
+		query = query.Where("LOWER(users.username) LIKE ? ESCAPE '\\'", escapeLike(prefix)+"%")
+	}
+	if cursor != "" {
+		after, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil || len(after) == 0 {
+			return nil, errors.ErrInvalidRequest
+		}
+		query = query.Where("users.username > ?", string(after))
+	}
+
+	// 다음 페이지가 있는지 알기 위해 하나 더 조회
+	var rows []struct {
+		UserID   uint
+		Username string
+		Role     string
+		IsGuest  bool
+		JoinedAt time.Time
+	}
+	if err := query.Order("users.username ASC").Limit(limit + 1).Scan(&rows).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	response := &dto.RoomMemberListResponse{Members: []dto.RoomMemberResponse{}}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		response.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(rows[limit-1].Username))
+	}
+
+	userIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		userIDs = append(userIDs, row.UserID)
+	}
+	online, err := s.presence.Online(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		response.Members = append(response.Members, dto.RoomMemberResponse{
+			UserID:   row.UserID,
+			Username: row.Username,
+			Role:     row.Role,
+			IsGuest:  row.IsGuest,
+			JoinedAt: row.JoinedAt,
+			Online:   online[row.UserID],
+		})
+	}
+
+	return response, nil
+}
+
+// escapeLike는 LIKE 패턴의 와일드카드 문자를 이스케이프합니다
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
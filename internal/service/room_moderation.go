@@ -0,0 +1,206 @@
+package service
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+)
+
+// RoomRemoval은 사용자가 채팅방에서 내보내졌거나 차단되었음을 나타냅니다
+type RoomRemoval struct {
+	RoomID uint   `json:"roomId"`
+	UserID uint   `json:"userId"`
+	Reason string `json:"reason,omitempty"`
+	Banned bool   `json:"banned"`
+}
+
+// RoomRemovalListener는 사용자가 채팅방에서 내보내질 때 호출됩니다 (예: 소켓 구독 해제, 다른 인스턴스에 전파)
+type RoomRemovalListener func(RoomRemoval)
+
+// OnMemberRemoved는 내보내기나 차단 시 호출될 리스너를 등록합니다
+func (s *RoomService) OnMemberRemoved(listener RoomRemovalListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removalListeners = append(s.removalListeners, listener)
+}
+
+// KickMember는 모더레이터 이상이 자기보다 낮은 역할의 멤버를 채팅방에서 내보냅니다. 내보낸 멤버는 다시 참여할 수 있습니다
+func (s *RoomService) KickMember(roomID, actorID, targetID uint, reason string) error {
+	actor, err := requireRoomRole(s.db, roomID, actorID, roomRoleKick)
+	if err != nil {
+		return err
+	}
+	if targetID == actorID {
+		return errors.ErrPermissionDenied
+	}
+
+	target, err := findRoomMember(s.db, roomID, targetID)
+	if err != nil {
+		return err
+	}
+	if !outranks(actor.Role, target.Role) {
+		return errors.ErrPermissionDenied
+	}
+
+	if err := s.db.Delete(target).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+
+	s.memberRemoved(actorID, RoomRemoval{RoomID: roomID, UserID: targetID, Reason: reason}, nil, true)
+	return nil
+}
+
+// BanMember는 사용자를 채팅방에서 차단하고, 멤버였다면 내보냅니다.
+// 멤버가 아닌 사용자도 미리 차단할 수 있으며, 이미 차단된 사용자는 새 사유와 기간으로 바꿉니다
+func (s *RoomService) BanMember(roomID, actorID, targetID uint, req dto.BanMemberRequest) (*dto.RoomBanResponse, error) {
+	actor, err := requireRoomRole(s.db, roomID, actorID, roomRoleKick)
+	if err != nil {
+		return nil, err
+	}
+	if targetID == actorID {
+		return nil, errors.ErrPermissionDenied
+	}
+
+	var user models.User
+	if err := s.db.Select("id", "username").First(&user, targetID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInvalidRequest
+		}
+		return nil, errors.ErrDatabaseError
+	}
+
+	target, err := findRoomMember(s.db, roomID, targetID)
+	if err != nil && err != errors.ErrMemberNotFound {
+		return nil, err
+	}
+	if target != nil && !outranks(actor.Role, target.Role) {
+		return nil, errors.ErrPermissionDenied
+	}
+
+	ban := models.RoomBan{
+		RoomID:   roomID,
+		UserID:   targetID,
+		BannedBy: actorID,
+		Reason:   req.Reason,
+	}
+	if req.DurationHours > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.DurationHours) * time.Hour)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 기존 차단(만료된 것 포함)은 새 차단으로 바꿈
+		if err := tx.Where("room_id = ? AND user_id = ?", roomID, targetID).Delete(&models.RoomBan{}).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if err := tx.Create(&ban).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if target != nil {
+			if err := tx.Delete(target).Error; err != nil {
+				return errors.ErrDatabaseError
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 공개 채팅방은 멤버가 아니어도 구독할 수 있으므로 멤버가 아니었어도 소켓 구독은 해제
+	s.memberRemoved(actorID, RoomRemoval{RoomID: roomID, UserID: targetID, Reason: req.Reason, Banned: true}, ban.ExpiresAt, target != nil)
+
+	return toRoomBanResponse(&ban, user.Username), nil
+}
+
+// UnbanMember는 채팅방 차단을 해제합니다. 해제된 사용자는 다시 참여해야 합니다
+func (s *RoomService) UnbanMember(roomID, actorID, targetID uint) error {
+	if _, err := requireRoomRole(s.db, roomID, actorID, roomRoleKick); err != nil {
+		return err
+	}
+
+	result := s.db.Where("room_id = ? AND user_id = ?", roomID, targetID).Delete(&models.RoomBan{})
+	if result.Error != nil {
+		return errors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrBanNotFound
+	}
+	return nil
+}
+
+// ListBans는 채팅방의 유효한 차단 목록을 반환합니다
+func (s *RoomService) ListBans(roomID, actorID uint) ([]dto.RoomBanResponse, error) {
+	if _, err := requireRoomRole(s.db, roomID, actorID, roomRoleKick); err != nil {
+		return nil, err
+	}
+
+	var bans []struct {
+		models.RoomBan
+		Username string
+	}
+	if err := s.db.Table("room_bans").
+		Select("room_bans.*, users.username").
+		Joins("LEFT JOIN users ON users.id = room_bans.user_id").
+		Where("room_bans.room_id = ? AND (room_bans.expires_at IS NULL OR room_bans.expires_at > ?)", roomID, time.Now()).
+		Order("room_bans.id").
+		Find(&bans).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	resp := make([]dto.RoomBanResponse, 0, len(bans))
+	for i := range bans {
+		resp = append(resp, *toRoomBanResponse(&bans[i].RoomBan, bans[i].Username))
+	}
+	return resp, nil
+}
+
+// memberRemoved는 리스너에게 알리고, 멤버십이 실제로 지워졌으면 member_removed 이벤트를 발행합니다
+func (s *RoomService) memberRemoved(actorID uint, removal RoomRemoval, bannedUntil *time.Time, wasMember bool) {
+	s.mu.RLock()
+	listeners := append([]RoomRemovalListener(nil), s.removalListeners...)
+	s.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(removal)
+	}
+
+	if !wasMember {
+		return
+	}
+	events.PublishOrLog(s.publisher, events.New(events.TypeRoomMemberRemoved, 1, events.RoomKey(removal.RoomID), events.RoomMemberRemoved{
+		RoomID:      removal.RoomID,
+		UserID:      removal.UserID,
+		RemovedBy:   actorID,
+		Reason:      removal.Reason,
+		Banned:      removal.Banned,
+		BannedUntil: bannedUntil,
+	}))
+}
+
+// isBanned는 사용자가 채팅방에서 차단되어 있는지 확인합니다. 기간이 지난 차단은 무시합니다
+func isBanned(db *gorm.DB, roomID, userID uint) (bool, error) {
+	var count int64
+	if err := db.Model(&models.RoomBan{}).
+		Where("room_id = ? AND user_id = ? AND (expires_at IS NULL OR expires_at > ?)", roomID, userID, time.Now()).
+		Count(&count).Error; err != nil {
+		return false, errors.ErrDatabaseError
+	}
+	return count > 0, nil
+}
+
+func toRoomBanResponse(ban *models.RoomBan, username string) *dto.RoomBanResponse {
+	return &dto.RoomBanResponse{
+		RoomID:    ban.RoomID,
+		UserID:    ban.UserID,
+		Username:  username,
+		BannedBy:  ban.BannedBy,
+		Reason:    ban.Reason,
+		ExpiresAt: ban.ExpiresAt,
+		CreatedAt: ban.CreatedAt,
+	}
+}
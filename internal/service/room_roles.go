@@ -0,0 +1,61 @@
+package service
+
+import (
+	"gorm.io/gorm"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+// 채팅방 작업별로 필요한 최소 역할
+const (
+	roomRolePost           = models.RoomRoleMember
+	roomRoleDeleteMessages = models.RoomRoleModerator // 다른 사람의 메시지 삭제
+	roomRoleInvite         = models.RoomRoleModerator
+	roomRoleKick           = models.RoomRoleModerator // 내보내기와 차단
+	roomRoleShareLinks     = models.RoomRoleAdmin
+	roomRoleEditSettings   = models.RoomRoleAdmin
+	roomRoleManageRoles    = models.RoomRoleAdmin
+)
+
+// requireRoomRole은 사용자가 채팅방에서 required 이상의 역할을 가졌는지 확인하고 멤버십을 반환합니다.
+// 멤버가 아니거나 역할이 낮으면 ErrPermissionDenied를 반환합니다
+func requireRoomRole(db *gorm.DB, roomID, userID uint, required string) (*models.RoomUser, error) {
+	var room models.Room
+	if err := db.First(&room, roomID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRoomNotFound
+		}
+		return nil, errors.ErrDatabaseError
+	}
+
+	member, err := findRoomMember(db, roomID, userID)
+	if err != nil {
+		if err == errors.ErrMemberNotFound {
+			return nil, errors.ErrPermissionDenied
+		}
+		return nil, err
+	}
+	if !models.RoomRoleAtLeast(member.Role, required) {
+		return nil, errors.ErrPermissionDenied
+	}
+	return member, nil
+}
+
+// findRoomMember는 채팅방 멤버십을 조회합니다. 멤버가 아니면 ErrMemberNotFound를 반환합니다
+func findRoomMember(db *gorm.DB, roomID, userID uint) (*models.RoomUser, error) {
+	var member models.RoomUser
+	result := db.Where("room_id = ? AND user_id = ?", roomID, userID).Limit(1).Find(&member)
+	if result.Error != nil {
+		return nil, errors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.ErrMemberNotFound
+	}
+	return &member, nil
+}
+
+// outranks는 actor 역할이 target 역할보다 높은지 확인합니다.
+// 역할을 바꾸거나 멤버를 내보낼 때는 자기보다 낮은 역할의 멤버만 다룰 수 있습니다
+func outranks(actor, target string) bool {
+	return !models.RoomRoleAtLeast(target, actor)
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"mult-working/internal/dto"
 	"mult-working/internal/errors"
+	"mult-working/internal/events"
 	"mult-working/internal/models"
+	"sync"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,21 +13,35 @@ import (
 
 // RoomService는 채팅방 관련 기능을 제공합니다
 type RoomService struct {
-	db *gorm.DB
+	db        *gorm.DB
+	publisher events.Publisher
+	presence  *PresenceStore
+
+	mu               sync.RWMutex
+	removalListeners []RoomRemovalListener
+	updateListeners  []RoomUpdateListener
 }
 
 // NewRoomService는 새로운 RoomService 인스턴스를 생성합니다
-func NewRoomService(db *gorm.DB) *RoomService {
+func NewRoomService(db *gorm.DB, publisher events.Publisher, presence *PresenceStore) *RoomService {
 	return &RoomService{
-		db: db,
+		db:        db,
+		publisher: publisher,
+		presence:  presence,
 	}
 }
 
 // CreateRoom은 새 채팅방을 생성합니다
 func (s *RoomService) CreateRoom(req dto.CreateRoomRequest, userID uint) (*dto.RoomResponse, error) {
+	visibility := req.Visibility
+	if visibility == "" {
+		visibility = models.RoomPublic
+	}
+
 	room := models.Room{
 		Name:        req.Name,
 		Description: req.Description,
+		Visibility:  visibility,
 		CreatedBy:   userID,
 	}
 
@@ -38,12 +54,12 @@ func (s *RoomService) CreateRoom(req dto.CreateRoomRequest, userID uint) (*dto.R
 		return nil, errors.ErrDatabaseError
 	}
 
-	// 생성자를 채팅방 관리자로 추가
+	// 생성자를 채팅방 소유자로 추가
 	roomUser := models.RoomUser{
 		RoomID:   room.ID,
 		UserID:   userID,
 		JoinedAt: time.Now(),
-		IsAdmin:  true,
+		Role:     models.RoomRoleOwner,
 	}
 
 	if err := tx.Create(&roomUser).Error; err != nil {
@@ -56,20 +72,31 @@ func (s *RoomService) CreateRoom(req dto.CreateRoomRequest, userID uint) (*dto.R
 		return nil, errors.ErrDatabaseError
 	}
 
+	events.PublishOrLog(s.publisher, events.New(events.TypeRoomCreated, 1, events.RoomKey(room.ID), events.RoomCreated{
+		RoomID:      room.ID,
+		Name:        room.Name,
+		Description: room.Description,
+		Visibility:  room.Visibility,
+		CreatedBy:   room.CreatedBy,
+	}))
+
 	return &dto.RoomResponse{
 		ID:          room.ID,
 		Name:        room.Name,
 		Description: room.Description,
+		Visibility:  room.Visibility,
+		Topic:       room.Topic,
+		ArchivedAt:  room.ArchivedAt,
 		CreatedBy:   room.CreatedBy,
 		CreatedAt:   room.CreatedAt,
 		UserCount:   1,
 	}, nil
 }
 
-// GetRooms는 모든 채팅방 목록을 반환합니다
+// GetRooms는 채팅방 목록을 반환합니다. 비공개 채팅방은 목록에 나오지 않습니다
 func (s *RoomService) GetRooms() ([]dto.RoomResponse, error) {
 	var rooms []models.Room
-	if err := s.db.Find(&rooms).Error; err != nil {
+	if err := s.db.Where("visibility <> ?", models.RoomPrivate).Find(&rooms).Error; err != nil {
 		return nil, errors.ErrDatabaseError
 	}
 
@@ -83,6 +110,9 @@ func (s *RoomService) GetRooms() ([]dto.RoomResponse, error) {
 			ID:          room.ID,
 			Name:        room.Name,
 			Description: room.Description,
+			Visibility:  room.Visibility,
+			Topic:       room.Topic,
+			ArchivedAt:  room.ArchivedAt,
 			CreatedBy:   room.CreatedBy,
 			CreatedAt:   room.CreatedAt,
 			UserCount:   int(userCount),
@@ -92,8 +122,8 @@ func (s *RoomService) GetRooms() ([]dto.RoomResponse, error) {
 	return response, nil
 }
 
-// GetRoom은 특정 채팅방 정보를 반환합니다
-func (s *RoomService) GetRoom(roomID uint) (*dto.RoomResponse, error) {
+// GetRoom은 특정 채팅방 정보를 반환합니다. 비공개 채팅방은 멤버가 아니면 없는 것처럼 보입니다
+func (s *RoomService) GetRoom(roomID, userID uint) (*dto.RoomResponse, error) {
 	var room models.Room
 	if err := s.db.First(&room, roomID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -102,6 +132,16 @@ func (s *RoomService) GetRoom(roomID uint) (*dto.RoomResponse, error) {
 		return nil, errors.ErrDatabaseError
 	}
 
+	if room.Visibility == models.RoomPrivate {
+		joined, err := isRoomMember(s.db, roomID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !joined {
+			return nil, errors.ErrRoomNotFound
+		}
+	}
+
 	// 채팅방 사용자 수 조회
 	var userCount int64
 	s.db.Model(&models.RoomUser{}).Where("room_id = ?", room.ID).Count(&userCount)
@@ -110,14 +150,32 @@ func (s *RoomService) GetRoom(roomID uint) (*dto.RoomResponse, error) {
 		ID:          room.ID,
 		Name:        room.Name,
 		Description: room.Description,
+		Visibility:  room.Visibility,
+		Topic:       room.Topic,
+		ArchivedAt:  room.ArchivedAt,
 		CreatedBy:   room.CreatedBy,
 		CreatedAt:   room.CreatedAt,
 		UserCount:   int(userCount),
 	}, nil
 }
 
-// JoinRoom은 사용자를 채팅방에 참여시킵니다
-func (s *RoomService) JoinRoom(roomID, userID uint) error {
+// JoinRoom은 사용자를 채팅방에 참여시킵니다.
+// 공개 채팅방이 아니면 유효한 초대 코드가 필요하며, roomID가 0이면 초대 코드의 채팅방에 참여합니다.
+// 비공개 채팅방은 초대 없이 참여하려 하면 없는 것처럼 보입니다
+func (s *RoomService) JoinRoom(roomID, userID uint, inviteCode string) error {
+	var invite *models.RoomInvite
+	if inviteCode != "" {
+		found, err := s.findInvite(inviteCode, userID)
+		if err != nil {
+			return err
+		}
+		if roomID != 0 && found.RoomID != roomID {
+			return errors.ErrInviteNotFound
+		}
+		roomID = found.RoomID
+		invite = found
+	}
+
 	// 채팅방 존재 여부 확인
 	var room models.Room
 	if err := s.db.First(&room, roomID).Error; err != nil {
@@ -127,28 +185,71 @@ func (s *RoomService) JoinRoom(roomID, userID uint) error {
 		return errors.ErrDatabaseError
 	}
 
-	// 이미 참여 중인지 확인
-	var count int64
-	if err := s.db.Model(&models.RoomUser{}).Where("room_id = ? AND user_id = ?", roomID, userID).Count(&count).Error; err != nil {
-		return errors.ErrDatabaseError
+	if invite == nil {
+		switch room.Visibility {
+		case models.RoomPrivate:
+			return errors.ErrRoomNotFound
+		case models.RoomInviteOnly:
+			return errors.ErrInviteRequired
+		}
 	}
 
-	if count > 0 {
-		return errors.ErrAlreadyJoined
+	if room.ArchivedAt != nil {
+		return errors.ErrRoomArchived
 	}
 
-	// 채팅방에 참여
-	roomUser := models.RoomUser{
-		RoomID:   roomID,
-		UserID:   userID,
-		JoinedAt: time.Now(),
-		IsAdmin:  false,
+	banned, err := isBanned(s.db, roomID, userID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return errors.ErrBanned
 	}
 
-	if err := s.db.Create(&roomUser).Error; err != nil {
-		return errors.ErrDatabaseError
+	// 이미 참여 중인지 확인 (초대 사용 횟수를 쓰기 전에)
+	joined, err := isRoomMember(s.db, roomID, userID)
+	if err != nil {
+		return err
+	}
+	if joined {
+		return errors.ErrAlreadyJoined
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if invite != nil {
+			// 동시에 들어온 요청이 사용 횟수를 넘기지 않도록 조건부로 증가
+			result := tx.Model(&models.RoomInvite{}).
+				Where("id = ? AND (max_uses = 0 OR uses < max_uses)", invite.ID).
+				Update("uses", gorm.Expr("uses + 1"))
+			if result.Error != nil {
+				return errors.ErrDatabaseError
+			}
+			if result.RowsAffected == 0 {
+				return errors.ErrInviteNotFound
+			}
+		}
+
+		// 채팅방에 참여
+		roomUser := models.RoomUser{
+			RoomID:   roomID,
+			UserID:   userID,
+			JoinedAt: time.Now(),
+			Role:     models.RoomRoleMember,
+		}
+		if err := tx.Create(&roomUser).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
+	events.PublishOrLog(s.publisher, events.New(events.TypeRoomMemberJoined, 1, events.RoomKey(roomID), events.RoomMemberJoined{
+		RoomID: roomID,
+		UserID: userID,
+	}))
+
 	return nil
 }
 
@@ -172,8 +273,8 @@ func (s *RoomService) LeaveRoom(roomID, userID uint) error {
 		return errors.ErrDatabaseError
 	}
 
-	// 채팅방 생성자는 나갈 수 없음
-	if room.CreatedBy == userID {
+	// 소유자는 소유권을 넘긴 뒤에만 나갈 수 있음
+	if roomUser.Role == models.RoomRoleOwner {
 		return errors.ErrCannotLeave
 	}
 
@@ -182,6 +283,11 @@ func (s *RoomService) LeaveRoom(roomID, userID uint) error {
 		return errors.ErrDatabaseError
 	}
 
+	events.PublishOrLog(s.publisher, events.New(events.TypeRoomMemberLeft, 1, events.RoomKey(roomID), events.RoomMemberLeft{
+		RoomID: roomID,
+		UserID: userID,
+	}))
+
 	return nil
 }
 
@@ -216,6 +322,9 @@ func (s *RoomService) GetUserRooms(userID uint) ([]dto.RoomResponse, error) {
 			ID:          room.ID,
 			Name:        room.Name,
 			Description: room.Description,
+			Visibility:  room.Visibility,
+			Topic:       room.Topic,
+			ArchivedAt:  room.ArchivedAt,
 			CreatedBy:   room.CreatedBy,
 			CreatedAt:   room.CreatedAt,
 			UserCount:   int(userCount),
@@ -224,3 +333,190 @@ func (s *RoomService) GetUserRooms(userID uint) ([]dto.RoomResponse, error) {
 
 	return response, nil
 }
+
+// CreateInvite는 채팅방 모더레이터 이상이 초대 코드를 만듭니다. 초대 코드 원문은 응답에만 담기고 저장되지 않습니다
+func (s *RoomService) CreateInvite(roomID, userID uint, req dto.CreateInviteRequest) (*dto.CreatedInviteResponse, error) {
+	if _, err := requireRoomRole(s.db, roomID, userID, roomRoleInvite); err != nil {
+		return nil, err
+	}
+
+	if req.InviteeID != nil {
+		var count int64
+		if err := s.db.Model(&models.User{}).Where("id = ? AND is_bot = ? AND is_guest = ?", *req.InviteeID, false, false).
+			Count(&count).Error; err != nil {
+			return nil, errors.ErrDatabaseError
+		}
+		if count == 0 {
+			return nil, errors.ErrInvalidRequest
+		}
+	}
+
+	code, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	invite := models.RoomInvite{
+		RoomID:    roomID,
+		CreatedBy: userID,
+		CodeHash:  hashToken(code),
+		InviteeID: req.InviteeID,
+		MaxUses:   req.MaxUses,
+	}
+	if req.ExpiresInHours > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		invite.ExpiresAt = &expiresAt
+	}
+	if err := s.db.Create(&invite).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	return &dto.CreatedInviteResponse{InviteResponse: toInviteResponse(&invite), Code: code}, nil
+}
+
+// ListInvites는 채팅방의 초대 코드 목록을 반환합니다 (폐기되거나 만료된 초대 포함)
+func (s *RoomService) ListInvites(roomID, userID uint) ([]dto.InviteResponse, error) {
+	if _, err := requireRoomRole(s.db, roomID, userID, roomRoleInvite); err != nil {
+		return nil, err
+	}
+
+	var invites []models.RoomInvite
+	if err := s.db.Where("room_id = ?", roomID).Order("id").Find(&invites).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	resp := make([]dto.InviteResponse, 0, len(invites))
+	for i := range invites {
+		resp = append(resp, toInviteResponse(&invites[i]))
+	}
+	return resp, nil
+}
+
+// RevokeInvite는 초대 코드를 폐기합니다. 이미 참여한 멤버에게는 영향이 없습니다
+func (s *RoomService) RevokeInvite(roomID, userID, inviteID uint) error {
+	if _, err := requireRoomRole(s.db, roomID, userID, roomRoleInvite); err != nil {
+		return err
+	}
+
+	result := s.db.Model(&models.RoomInvite{}).
+		Where("id = ? AND room_id = ? AND revoked_at IS NULL", inviteID, roomID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return errors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrInviteNotFound
+	}
+	return nil
+}
+
+// findInvite는 사용자가 지금 쓸 수 있는 초대를 찾습니다. 폐기, 만료, 소진되었거나 다른 사용자에게 보낸 초대는 없는 것으로 봅니다
+func (s *RoomService) findInvite(code string, userID uint) (*models.RoomInvite, error) {
+	var invite models.RoomInvite
+	if err := s.db.Where("code_hash = ? AND revoked_at IS NULL", hashToken(code)).First(&invite).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrInviteNotFound
+		}
+		return nil, errors.ErrDatabaseError
+	}
+
+	if invite.ExpiresAt != nil && !invite.ExpiresAt.After(time.Now()) {
+		return nil, errors.ErrInviteNotFound
+	}
+	if invite.MaxUses > 0 && invite.Uses >= invite.MaxUses {
+		return nil, errors.ErrInviteNotFound
+	}
+	if invite.InviteeID != nil && *invite.InviteeID != userID {
+		return nil, errors.ErrInviteNotFound
+	}
+	return &invite, nil
+}
+
+// isRoomMember는 사용자가 채팅방 멤버인지 확인합니다
+func isRoomMember(db *gorm.DB, roomID, userID uint) (bool, error) {
+	var count int64
+	if err := db.Model(&models.RoomUser{}).Where("room_id = ? AND user_id = ?", roomID, userID).Count(&count).Error; err != nil {
+		return false, errors.ErrDatabaseError
+	}
+	return count > 0, nil
+}
+
+func toInviteResponse(invite *models.RoomInvite) dto.InviteResponse {
+	return dto.InviteResponse{
+		ID:        invite.ID,
+		RoomID:    invite.RoomID,
+		CreatedBy: invite.CreatedBy,
+		InviteeID: invite.InviteeID,
+		MaxUses:   invite.MaxUses,
+		Uses:      invite.Uses,
+		ExpiresAt: invite.ExpiresAt,
+		RevokedAt: invite.RevokedAt,
+		CreatedAt: invite.CreatedAt,
+	}
+}
+
+// guestRoleCeiling은 게스트에게 줄 수 있는 가장 높은 채팅방 역할입니다.
+// 부여 역할이 기록되기 전에 들어온 게스트는 읽기 전용으로 제한합니다
+func guestRoleCeiling(user *models.User) string {
+	if user.GuestRoomRole == "" {
+		return models.RoomRoleReadOnly
+	}
+	return user.GuestRoomRole
+}
+
+// SetMemberRole은 채팅방 멤버의 역할을 바꿉니다 (승격, 강등).
+// 관리자 이상만 바꿀 수 있고, 자기보다 낮은 역할의 멤버를 자기보다 낮은 역할로만 바꿀 수 있습니다.
+// 게스트는 공유 링크가 부여한 역할(member 또는 read_only)까지만 가질 수 있습니다
+func (s *RoomService) SetMemberRole(roomID, actorID, targetID uint, role string) (*dto.RoomMemberResponse, error) {
+	if !models.ValidRoomRole(role) || role == models.RoomRoleOwner {
+		return nil, errors.ErrInvalidRequest
+	}
+
+	actor, err := requireRoomRole(s.db, roomID, actorID, roomRoleManageRoles)
+	if err != nil {
+		return nil, err
+	}
+	if targetID == actorID {
+		return nil, errors.ErrPermissionDenied
+	}
+
+	target, err := findRoomMember(s.db, roomID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if !outranks(actor.Role, target.Role) || !outranks(actor.Role, role) {
+		return nil, errors.ErrPermissionDenied
+	}
+
+	var user models.User
+	if err := s.db.Select("id", "username", "is_guest", "guest_room_role").First(&user, targetID).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+	if user.IsGuest && !models.RoomRoleAtLeast(guestRoleCeiling(&user), role) {
+		// 게스트는 공유 링크가 부여한 역할보다 높일 수 없음 (읽기 전용 링크 게스트에게 쓰기 권한 부여 방지)
+		return nil, errors.ErrInvalidRequest
+	}
+
+	if target.Role != role {
+		if err := s.db.Model(&models.RoomUser{}).
+			Where("room_id = ? AND user_id = ?", roomID, targetID).
+			Update("role", role).Error; err != nil {
+			return nil, errors.ErrDatabaseError
+		}
+
+		events.PublishOrLog(s.publisher, events.New(events.TypeRoomRoleChanged, 1, events.RoomKey(roomID), events.RoomRoleChanged{
+			RoomID:    roomID,
+			UserID:    targetID,
+			Role:      role,
+			ChangedBy: actorID,
+		}))
+	}
+
+	return &dto.RoomMemberResponse{
+		UserID:   targetID,
+		Username: user.Username,
+		Role:     role,
+		IsGuest:  user.IsGuest,
+		JoinedAt: target.JoinedAt,
+	}, nil
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+)
+
+// RoomUpdate는 채팅방 설정이 바뀌었거나(보관, 보관 해제 포함) 채팅방이 삭제되었음을 나타냅니다
+type RoomUpdate struct {
+	Room    dto.RoomResponse
+	Deleted bool
+	// RevokedGuests는 삭제된 채팅방에 묶여 있어 토큰을 폐기한 게스트입니다
+	RevokedGuests []uint
+}
+
+// RoomUpdateListener는 채팅방이 바뀌거나 삭제될 때 호출됩니다 (예: 채팅방 소켓에 알림)
+type RoomUpdateListener func(RoomUpdate)
+
+// OnRoomUpdated는 채팅방 변경, 삭제 시 호출될 리스너를 등록합니다
+func (s *RoomService) OnRoomUpdated(listener RoomUpdateListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updateListeners = append(s.updateListeners, listener)
+}
+
+// UpdateRoom은 관리자 이상이 채팅방 이름, 설명, 주제를 바꿉니다. 보관된 채팅방은 바꿀 수 없습니다
+func (s *RoomService) UpdateRoom(roomID, userID uint, req dto.UpdateRoomRequest) (*dto.RoomResponse, error) {
+	if _, err := requireRoomRole(s.db, roomID, userID, roomRoleEditSettings); err != nil {
+		return nil, err
+	}
+
+	room, err := s.loadRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.ArchivedAt != nil {
+		return nil, errors.ErrRoomArchived
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if len(name) < 3 {
+			return nil, errors.ErrInvalidRequest
+		}
+		updates["name"] = name
+	}
+	if req.Description != nil {
+		updates["description"] = strings.TrimSpace(*req.Description)
+	}
+	if req.Topic != nil {
+		updates["topic"] = strings.TrimSpace(*req.Topic)
+	}
+	if len(updates) == 0 {
+		return s.roomResponse(room)
+	}
+
+	if err := s.db.Model(room).Updates(updates).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+	return s.roomUpdated(room, userID)
+}
+
+// ArchiveRoom은 관리자 이상이 채팅방을 보관합니다. 보관된 채팅방은 메시지를 쓰거나 새로 참여할 수 없습니다
+func (s *RoomService) ArchiveRoom(roomID, userID uint) (*dto.RoomResponse, error) {
+	return s.setArchived(roomID, userID, true)
+}
+
+// UnarchiveRoom은 보관된 채팅방을 다시 쓸 수 있게 합니다
+func (s *RoomService) UnarchiveRoom(roomID, userID uint) (*dto.RoomResponse, error) {
+	return s.setArchived(roomID, userID, false)
+}
+
+func (s *RoomService) setArchived(roomID, userID uint, archived bool) (*dto.RoomResponse, error) {
+	if _, err := requireRoomRole(s.db, roomID, userID, roomRoleEditSettings); err != nil {
+		return nil, err
+	}
+
+	room, err := s.loadRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if (room.ArchivedAt != nil) == archived {
+		return s.roomResponse(room)
+	}
+
+	var archivedAt *time.Time
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+	}
+	if err := s.db.Model(room).Update("archived_at", archivedAt).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+	room.ArchivedAt = archivedAt
+	return s.roomUpdated(room, userID)
+}
+
+// DeleteRoom은 소유자가 채팅방을 삭제합니다.
+// 멤버십, 초대, 차단은 지우고 공유 링크와 채팅방 게스트의 토큰은 폐기하며, 채팅방과 메시지는 소프트 삭제합니다
+func (s *RoomService) DeleteRoom(roomID, userID uint) error {
+	if _, err := requireRoomRole(s.db, roomID, userID, models.RoomRoleOwner); err != nil {
+		return err
+	}
+
+	room, err := s.loadRoom(roomID)
+	if err != nil {
+		return err
+	}
+	resp, err := s.roomResponse(room)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var guests []uint
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// 게스트는 이 채팅방에만 접근할 수 있으므로 토큰을 폐기하고 계정을 만료시킴 (다음 정리 때 삭제)
+		if err := tx.Model(&models.User{}).
+			Where("is_guest = ? AND id IN (?)", true, tx.Model(&models.RoomUser{}).Select("user_id").Where("room_id = ?", roomID)).
+			Pluck("id", &guests).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if len(guests) > 0 {
+			if err := tx.Model(&models.User{}).Where("id IN ?", guests).Updates(map[string]interface{}{
+				"tokens_revoked_at": now.Truncate(time.Microsecond).Add(time.Microsecond),
+				"expires_at":        now,
+			}).Error; err != nil {
+				return errors.ErrDatabaseError
+			}
+			if err := tx.Model(&models.Session{}).
+				Where("user_id IN ? AND revoked_at IS NULL", guests).
+				Update("revoked_at", now).Error; err != nil {
+				return errors.ErrDatabaseError
+			}
+		}
+
+		if err := tx.Where("room_id = ?", roomID).Delete(&models.Message{}).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if err := tx.Where("room_id = ?", roomID).Delete(&models.RoomUser{}).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if err := tx.Where("room_id = ?", roomID).Delete(&models.RoomInvite{}).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if err := tx.Where("room_id = ?", roomID).Delete(&models.RoomBan{}).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if err := tx.Model(&models.RoomShareLink{}).
+			Where("room_id = ? AND revoked_at IS NULL", roomID).
+			Update("revoked_at", now).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if err := tx.Delete(room).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyRoomUpdate(RoomUpdate{Room: *resp, Deleted: true, RevokedGuests: guests})
+	events.PublishOrLog(s.publisher, events.New(events.TypeRoomDeleted, 1, events.RoomKey(roomID), events.RoomDeleted{
+		RoomID:    roomID,
+		DeletedBy: userID,
+	}))
+	return nil
+}
+
+// TransferOwnership은 소유자가 다른 멤버에게 채팅방 소유권을 넘깁니다. 이전 소유자는 관리자가 되어 채팅방을 나갈 수 있습니다.
+// 게스트, 봇, 읽기 전용 멤버에게는 넘길 수 없습니다
+func (s *RoomService) TransferOwnership(roomID, ownerID, targetID uint) error {
+	if _, err := requireRoomRole(s.db, roomID, ownerID, models.RoomRoleOwner); err != nil {
+		return err
+	}
+	if targetID == ownerID {
+		return errors.ErrInvalidRequest
+	}
+
+	target, err := findRoomMember(s.db, roomID, targetID)
+	if err != nil {
+		return err
+	}
+	if !models.RoomRoleAtLeast(target.Role, models.RoomRoleMember) {
+		return errors.ErrInvalidRequest
+	}
+
+	var user models.User
+	if err := s.db.Select("id", "is_guest", "is_bot").First(&user, targetID).Error; err != nil {
+		return errors.ErrDatabaseError
+	}
+	if user.IsGuest || user.IsBot {
+		return errors.ErrInvalidRequest
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.RoomUser{}).
+			Where("room_id = ? AND user_id = ?", roomID, ownerID).
+			Update("role", models.RoomRoleAdmin).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if err := tx.Model(&models.RoomUser{}).
+			Where("room_id = ? AND user_id = ?", roomID, targetID).
+			Update("role", models.RoomRoleOwner).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, change := range []events.RoomRoleChanged{
+		{RoomID: roomID, UserID: targetID, Role: models.RoomRoleOwner, ChangedBy: ownerID},
+		{RoomID: roomID, UserID: ownerID, Role: models.RoomRoleAdmin, ChangedBy: ownerID},
+	} {
+		events.PublishOrLog(s.publisher, events.New(events.TypeRoomRoleChanged, 1, events.RoomKey(roomID), change))
+	}
+	return nil
+}
+
+func (s *RoomService) loadRoom(roomID uint) (*models.Room, error) {
+	var room models.Room
+	if err := s.db.First(&room, roomID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrRoomNotFound
+		}
+		return nil, errors.ErrDatabaseError
+	}
+	return &room, nil
+}
+
+func (s *RoomService) roomResponse(room *models.Room) (*dto.RoomResponse, error) {
+	var userCount int64
+	if err := s.db.Model(&models.RoomUser{}).Where("room_id = ?", room.ID).Count(&userCount).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	return &dto.RoomResponse{
+		ID:          room.ID,
+		Name:        room.Name,
+		Description: room.Description,
+		Visibility:  room.Visibility,
+		Topic:       room.Topic,
+		ArchivedAt:  room.ArchivedAt,
+		CreatedBy:   room.CreatedBy,
+		CreatedAt:   room.CreatedAt,
+		UserCount:   int(userCount),
+	}, nil
+}
+
+// roomUpdated는 바뀐 채팅방을 리스너에게 알리고 room.updated 이벤트를 발행합니다
+func (s *RoomService) roomUpdated(room *models.Room, userID uint) (*dto.RoomResponse, error) {
+	resp, err := s.roomResponse(room)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRoomUpdate(RoomUpdate{Room: *resp})
+	events.PublishOrLog(s.publisher, events.New(events.TypeRoomUpdated, 1, events.RoomKey(room.ID), events.RoomUpdated{
+		RoomID:      room.ID,
+		Name:        room.Name,
+		Description: room.Description,
+		Topic:       room.Topic,
+		ArchivedAt:  room.ArchivedAt,
+		UpdatedBy:   userID,
+	}))
+	return resp, nil
+}
+
+func (s *RoomService) notifyRoomUpdate(update RoomUpdate) {
+	s.mu.RLock()
+	listeners := append([]RoomUpdateListener(nil), s.updateListeners...)
+	s.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(update)
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// sessionSeenResolution보다 자주 요청해도 세션의 마지막 접속 시각은 한 번만 갱신합니다
+const sessionSeenResolution = time.Minute
+
+// sessionTouchLimit를 넘으면 오래된 세션 갱신 기록을 정리합니다
+const sessionTouchLimit = 10000
+
+// ListSessions는 사용자의 활성 세션을 최근 접속 순으로 반환합니다. currentSessionID인 세션에는 Current가 표시됩니다
+func (s *AuthService) ListSessions(userID uint, currentSessionID string) ([]dto.SessionResponse, error) {
+	var sessions []models.Session
+	if err := s.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").Find(&sessions).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	resp := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, dto.SessionResponse{
+			ID:         session.ID,
+			Device:     session.Device,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return resp, nil
+}
+
+// RevokeSession은 사용자의 세션 하나를 폐기합니다.
+// 세션의 리프레시 토큰과 액세스 토큰이 무효가 되고, 세션으로 연결된 웹소켓도 끊깁니다
+func (s *AuthService) RevokeSession(userID uint, sessionID string) error {
+	if err := s.revocations.RevokeSession(userID, sessionID); err != nil {
+		return err
+	}
+	return s.revokeRefreshFamily(s.db, sessionID)
+}
+
+// startGuestSession은 게스트의 세션을 만들고 roomID 채팅방에만 쓸 수 있는 액세스 토큰을 발급합니다.
+// 게스트 토큰은 리프레시 토큰 없이 게스트 계정이 만료될 때까지 유효합니다
+func (s *AuthService) startGuestSession(tx *gorm.DB, userID, roomID uint, scopes []string, expiresAt time.Time, client ClientInfo) (string, error) {
+	sessionID, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+	tokenID, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	session := models.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		Device:     describeDevice(client.UserAgent),
+		UserAgent:  truncate(client.UserAgent, 512),
+		IP:         client.IP,
+		LastSeenAt: now,
+		ExpiresAt:  expiresAt,
+	}
+	if err := tx.Create(&session).Error; err != nil {
+		return "", errors.ErrDatabaseError
+	}
+
+	return s.tokens.encode(TokenClaims{
+		UserID:       userID,
+		TokenID:      tokenID,
+		SessionID:    sessionID,
+		IssuedAt:     now.Unix(),
+		IssuedAtNano: now.UnixNano(),
+		ExpiresAt:    expiresAt.Unix(),
+		GuestRoomID:  roomID,
+		Scopes:       scopes,
+	})
+}
+
+// touchSession은 세션의 마지막 접속 시각을 갱신합니다. 실패해도 요청은 막지 않습니다
+func (s *AuthService) touchSession(sessionID string) {
+	if sessionID == "" {
+		return
+	}
+
+	// 이 인스턴스에서 최근에 갱신한 세션은 DB에 묻지 않음
+	now := time.Now()
+	s.touchMu.Lock()
+	if last, ok := s.sessionTouches[sessionID]; ok && now.Sub(last) < sessionSeenResolution {
+		s.touchMu.Unlock()
+		return
+	}
+	if len(s.sessionTouches) >= sessionTouchLimit {
+		for id, last := range s.sessionTouches {
+			if now.Sub(last) >= sessionSeenResolution {
+				delete(s.sessionTouches, id)
+			}
+		}
+	}
+	s.sessionTouches[sessionID] = now
+	s.touchMu.Unlock()
+
+	if err := s.db.Model(&models.Session{}).
+		Where("id = ? AND last_seen_at < ?", sessionID, now.Add(-sessionSeenResolution)).
+		Update("last_seen_at", now).Error; err != nil {
+		log.Printf("Failed to update session last seen time: %v", err)
+	}
+}
+
+// purgeExpiredSessions는 만료된 세션 기록을 정리합니다
+func (s *AuthService) purgeExpiredSessions() {
+	if err := s.db.Where("expires_at < ?", time.Now()).Delete(&models.Session{}).Error; err != nil {
+		log.Printf("Failed to purge expired sessions: %v", err)
+	}
+}
+
+// describeDevice는 User-Agent에서 "Chrome on Windows" 같은 짧은 기기 설명을 만듭니다
+func describeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	var browser string
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(userAgent, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	}
+
+	var os string
+	switch {
+	case strings.Contains(userAgent, "Windows"):
+		os = "Windows"
+	case strings.Contains(userAgent, "Android"):
+		os = "Android"
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"):
+		os = "iOS"
+	case strings.Contains(userAgent, "Mac OS X"):
+		os = "macOS"
+	case strings.Contains(userAgent, "Linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " on " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	}
+
+	// 브라우저가 아닌 클라이언트 (curl/8.0, okhttp/4.12 등)는 제품 이름만 사용
+	product, _, _ := strings.Cut(userAgent, "/")
+	return truncate(strings.TrimSpace(product), 100)
+}
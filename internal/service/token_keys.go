@@ -0,0 +1,327 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/o1egl/paseto"
+	"mult-working/internal/config"
+)
+
+// 토큰 모드
+const (
+	// TokenModeLocal은 대칭 키로 암호화하는 v2.local 토큰입니다. 검증하려면 같은 비밀 키가 필요합니다
+	TokenModeLocal = "local"
+	// TokenModePublic은 Ed25519로 서명하는 v2.public 토큰입니다. 공개 키만으로 검증할 수 있습니다
+	TokenModePublic = "public"
+)
+
+// symmetricKeySize는 v2.local(XChaCha20-Poly1305) 키 길이입니다
+const symmetricKeySize = 32
+
+// devKeyID는 개발 모드에서 키가 설정되지 않았을 때 생성하는 임시 키의 ID입니다
+const devKeyID = "dev-ephemeral"
+
+// tokenFooter는 토큰 푸터에 담기는 메타데이터입니다
+type tokenFooter struct {
+	KeyID string `json:"kid,omitempty"`
+}
+
+// PublicKey는 다른 서비스가 토큰을 오프라인으로 검증할 때 사용하는 공개 키입니다
+type PublicKey struct {
+	KeyID   string `json:"kid"`
+	Version string `json:"version"`
+	Purpose string `json:"purpose"`
+	// Key는 설정 파일의 키와 같은 표준 base64(패딩 포함)로 인코딩한 Ed25519 공개 키입니다
+	Key string `json:"key"`
+}
+
+// tokenCodec은 활성 키로 토큰을 발급하고, 푸터의 키 ID로 찾은 키로 검증합니다
+type tokenCodec interface {
+	encode(payload interface{}) (string, error)
+	decode(token string, payload interface{}) error
+	publicKeys() []PublicKey
+}
+
+// newTokenCodec은 설정된 키를 검증하고 모드에 맞는 tokenCodec을 생성합니다.
+// 키 길이나 엔트로피가 부족하면 모든 문제를 모아 에러로 반환합니다
+func newTokenCodec(cfg config.AuthConfig) (tokenCodec, error) {
+	var errs []error
+
+	if len(cfg.Keys) == 0 && cfg.ActiveKeyID == "" && cfg.DevMode {
+		// 개발 모드에서만 임시 키로 시작 (재시작하면 모든 토큰이 무효)
+		key, err := GenerateKey(cfg.TokenMode)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Keys = []config.AuthKeyConfig{{ID: devKeyID, Secret: key.Secret, PrivateKey: key.PrivateKey}}
+		cfg.ActiveKeyID = devKeyID
+		log.Printf("WARNING: auth.keys is not configured, using an ephemeral key for debug mode (tokens are invalidated on restart)")
+	}
+	if len(cfg.Keys) == 0 {
+		errs = append(errs, errors.New("auth.keys: at least one key is required outside debug mode (generate one with `go run ./cmd/admin generate-key`)"))
+	}
+
+	seen := make(map[string]bool)
+	for i, k := range cfg.Keys {
+		if k.ID == "" {
+			errs = append(errs, fmt.Errorf("auth.keys[%d].id: required", i))
+		} else if seen[k.ID] {
+			errs = append(errs, fmt.Errorf("auth.keys[%d].id: duplicate key id %q", i, k.ID))
+		}
+		seen[k.ID] = true
+	}
+	if !seen[cfg.ActiveKeyID] {
+		errs = append(errs, fmt.Errorf("auth.active_key_id: %q does not match any configured key", cfg.ActiveKeyID))
+	}
+
+	var codec tokenCodec
+	switch cfg.TokenMode {
+	case "", TokenModeLocal:
+		c, keyErrs := newLocalCodec(cfg)
+		codec, errs = c, append(errs, keyErrs...)
+	case TokenModePublic:
+		c, keyErrs := newPublicCodec(cfg)
+		codec, errs = c, append(errs, keyErrs...)
+	default:
+		errs = append(errs, fmt.Errorf("auth.token_mode: %q is not supported (use %q or %q)", cfg.TokenMode, TokenModeLocal, TokenModePublic))
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid auth key configuration: %w", errors.Join(errs...))
+	}
+
+	if cfg.SymmetricKey != "" {
+		log.Printf("WARNING: auth.symmetric_key is deprecated and will be removed in the next release; it is only used to verify tokens issued before key IDs (configure auth.keys instead)")
+		codec = &legacyCodec{tokenCodec: codec, paseto: paseto.NewV2(), key: legacySymmetricKey(cfg.SymmetricKey)}
+	}
+	return codec, nil
+}
+
+// legacySymmetricKey는 이전 버전과 같은 방식(문자열 바이트를 32바이트로 자르거나 0으로 채움)으로 키를 만듭니다
+func legacySymmetricKey(secret string) []byte {
+	key := make([]byte, symmetricKeySize)
+	copy(key, secret)
+	return key
+}
+
+// legacyCodec은 푸터(키 ID)가 없는 이전 v2.local 토큰을 auth.symmetric_key로 검증합니다.
+// 새 토큰은 발급하지 않는 검증 전용 키입니다
+type legacyCodec struct {
+	tokenCodec
+	paseto *paseto.V2
+	key    []byte
+}
+
+func (c *legacyCodec) decode(token string, payload interface{}) error {
+	// 이전 토큰은 푸터가 없거나 "null"이라 키 ID가 없음
+	var footer tokenFooter
+	if strings.HasPrefix(token, "v2.local.") && paseto.ParseFooter(token, &footer) == nil && footer.KeyID == "" {
+		return c.paseto.Decrypt(token, c.key, payload, nil)
+	}
+	return c.tokenCodec.decode(token, payload)
+}
+
+// decodeKey는 base64 키를 디코딩하고 길이와 엔트로피를 검사합니다
+func decodeKey(field, encoded string, sizes ...int) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid base64: %w", field, err)
+	}
+
+	if !slices.Contains(sizes, len(raw)) {
+		return nil, fmt.Errorf("%s: expected %v bytes after base64 decoding, got %d", field, sizes, len(raw))
+	}
+
+	if err := checkKeyEntropy(raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", field, err)
+	}
+	return raw, nil
+}
+
+// checkKeyEntropy는 사람이 입력한 문자열이나 반복 패턴처럼 보이는 키를 거부합니다.
+// 32바이트 랜덤 키가 이 검사에 걸릴 확률은 무시할 수 있을 만큼 작습니다
+func checkKeyEntropy(raw []byte) error {
+	distinct := make(map[byte]bool)
+	printable := true
+	for _, b := range raw {
+		distinct[b] = true
+		if b < 0x20 || b > 0x7e {
+			printable = false
+		}
+	}
+
+	if len(distinct) < len(raw)/2 {
+		return fmt.Errorf("low entropy: only %d distinct byte values", len(distinct))
+	}
+	if printable {
+		return errors.New("low entropy: key consists of printable ASCII only, which looks like a passphrase rather than random bytes")
+	}
+	return nil
+}
+
+// localCodec은 v2.local 토큰을 사용합니다
+type localCodec struct {
+	paseto   *paseto.V2
+	activeID string
+	keys     map[string][]byte
+}
+
+func newLocalCodec(cfg config.AuthConfig) (*localCodec, []error) {
+	var errs []error
+	keys := make(map[string][]byte)
+	for i, k := range cfg.Keys {
+		field := fmt.Sprintf("auth.keys[%d].secret", i)
+		if k.Secret == "" {
+			errs = append(errs, fmt.Errorf("%s: required when token_mode is %q", field, TokenModeLocal))
+			continue
+		}
+		raw, err := decodeKey(field, k.Secret, symmetricKeySize)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		keys[k.ID] = raw
+	}
+
+	return &localCodec{paseto: paseto.NewV2(), activeID: cfg.ActiveKeyID, keys: keys}, errs
+}
+
+func (c *localCodec) encode(payload interface{}) (string, error) {
+	return c.paseto.Encrypt(c.keys[c.activeID], payload, tokenFooter{KeyID: c.activeID})
+}
+
+func (c *localCodec) decode(token string, payload interface{}) error {
+	var footer tokenFooter
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		return err
+	}
+	key, ok := c.keys[footer.KeyID]
+	if !ok {
+		return fmt.Errorf("unknown key id %q", footer.KeyID)
+	}
+	return c.paseto.Decrypt(token, key, payload, nil)
+}
+
+func (c *localCodec) publicKeys() []PublicKey {
+	return []PublicKey{}
+}
+
+// publicCodec은 v2.public 토큰을 사용합니다. 개인 키는 이 서비스에만 있습니다
+type publicCodec struct {
+	paseto     *paseto.V2
+	activeID   string
+	privateKey ed25519.PrivateKey
+	keys       map[string]ed25519.PublicKey
+}
+
+func newPublicCodec(cfg config.AuthConfig) (*publicCodec, []error) {
+	var errs []error
+	c := &publicCodec{paseto: paseto.NewV2(), activeID: cfg.ActiveKeyID, keys: make(map[string]ed25519.PublicKey)}
+
+	for i, k := range cfg.Keys {
+		switch {
+		case k.PrivateKey != "":
+			raw, err := decodeKey(fmt.Sprintf("auth.keys[%d].private_key", i), k.PrivateKey, ed25519.SeedSize, ed25519.PrivateKeySize)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			private := ed25519.PrivateKey(raw)
+			if len(raw) == ed25519.SeedSize {
+				private = ed25519.NewKeyFromSeed(raw)
+			}
+			c.keys[k.ID] = private.Public().(ed25519.PublicKey)
+			if k.ID == cfg.ActiveKeyID {
+				c.privateKey = private
+			}
+		case k.PublicKey != "":
+			// 검증 전용 키
+			raw, err := base64.StdEncoding.DecodeString(k.PublicKey)
+			if err != nil || len(raw) != ed25519.PublicKeySize {
+				errs = append(errs, fmt.Errorf("auth.keys[%d].public_key: expected base64 %d-byte Ed25519 public key", i, ed25519.PublicKeySize))
+				continue
+			}
+			c.keys[k.ID] = ed25519.PublicKey(raw)
+		default:
+			errs = append(errs, fmt.Errorf("auth.keys[%d]: private_key or public_key is required when token_mode is %q", i, TokenModePublic))
+		}
+	}
+
+	if c.privateKey == nil {
+		errs = append(errs, fmt.Errorf("auth.active_key_id: active key %q must have a private_key", cfg.ActiveKeyID))
+	}
+	return c, errs
+}
+
+func (c *publicCodec) encode(payload interface{}) (string, error) {
+	return c.paseto.Sign(c.privateKey, payload, tokenFooter{KeyID: c.activeID})
+}
+
+func (c *publicCodec) decode(token string, payload interface{}) error {
+	var footer tokenFooter
+	if err := paseto.ParseFooter(token, &footer); err != nil {
+		return err
+	}
+	key, ok := c.keys[footer.KeyID]
+	if !ok {
+		return fmt.Errorf("unknown key id %q", footer.KeyID)
+	}
+	return c.paseto.Verify(token, key, payload, nil)
+}
+
+func (c *publicCodec) publicKeys() []PublicKey {
+	ids := make([]string, 0, len(c.keys))
+	for id := range c.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	keys := make([]PublicKey, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, PublicKey{
+			KeyID:   id,
+			Version: "v2",
+			Purpose: TokenModePublic,
+			Key:     base64.StdEncoding.EncodeToString(c.keys[id]),
+		})
+	}
+	return keys
+}
+
+// GeneratedKey는 새로 생성된 키입니다. Secret(local) 또는 PrivateKey/PublicKey(public)가 채워집니다
+type GeneratedKey struct {
+	Secret     string
+	PrivateKey string
+	PublicKey  string
+}
+
+// GenerateKey는 지정된 모드의 새 랜덤 키를 base64로 생성합니다
+func GenerateKey(mode string) (*GeneratedKey, error) {
+	switch mode {
+	case "", TokenModeLocal:
+		raw := make([]byte, symmetricKeySize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		return &GeneratedKey{Secret: base64.StdEncoding.EncodeToString(raw)}, nil
+	case TokenModePublic:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return &GeneratedKey{
+			PrivateKey: base64.StdEncoding.EncodeToString(private.Seed()),
+			PublicKey:  base64.StdEncoding.EncodeToString(public),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported token mode %q", mode)
+	}
+}
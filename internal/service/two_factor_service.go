@@ -0,0 +1,242 @@
+package service
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+	"mult-working/pkg/totp"
+)
+
+const (
+	// recoveryCodeCount는 한 번에 발급하는 복구 코드 수입니다
+	recoveryCodeCount = 10
+	// totpSkew는 시계 오차를 고려해 허용하는 앞뒤 시간 단계 수입니다
+	totpSkew = 1
+)
+
+// 복구 코드 문자 (헷갈리기 쉬운 0/o, 1/l 제외)
+const recoveryCodeAlphabet = "23456789abcdefghjkmnpqrstuvwxyz"
+
+// TwoFactorEnrollment는 인증 앱 등록에 필요한 정보입니다
+type TwoFactorEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TwoFactorService는 TOTP 2단계 인증 등록과 코드 검증을 제공합니다
+type TwoFactorService struct {
+	db     *gorm.DB
+	issuer string
+}
+
+// NewTwoFactorService는 새 TwoFactorService 인스턴스를 생성합니다
+func NewTwoFactorService(db *gorm.DB, issuer string) *TwoFactorService {
+	if issuer == "" {
+		issuer = "mult-working"
+	}
+	return &TwoFactorService{db: db, issuer: issuer}
+}
+
+// Enabled는 사용자가 2단계 인증을 사용 중인지 반환합니다
+func (s *TwoFactorService) Enabled(userID uint) (bool, error) {
+	var count int64
+	if err := s.db.Model(&models.UserTwoFactor{}).Where("user_id = ? AND enabled_at IS NOT NULL", userID).Count(&count).Error; err != nil {
+		return false, errors.ErrDatabaseError
+	}
+	return count > 0, nil
+}
+
+// Enroll은 새 TOTP 비밀 키를 만들고 등록 URI를 반환합니다. Confirm으로 코드를 확인해야 사용이 시작됩니다
+func (s *TwoFactorService) Enroll(userID uint) (*TwoFactorEnrollment, error) {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	enabled, err := s.Enabled(userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, errors.ErrTwoFactorEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	// 확인되지 않은 이전 등록은 새 비밀 키로 교체
+	record := models.UserTwoFactor{UserID: userID, Secret: secret}
+	if err := s.db.Save(&record).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm은 인증 앱의 코드로 등록을 확인하고 2단계 인증을 켠 뒤 복구 코드를 발급합니다
+func (s *TwoFactorService) Confirm(userID uint, code string) ([]string, error) {
+	var codes []string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var record models.UserTwoFactor
+		if err := tx.Where("user_id = ?", userID).First(&record).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrTwoFactorDisabled
+			}
+			return errors.ErrDatabaseError
+		}
+		if record.EnabledAt != nil {
+			return errors.ErrTwoFactorEnabled
+		}
+
+		step, ok := totp.Validate(record.Secret, code, time.Now(), totpSkew)
+		if !ok {
+			return errors.ErrInvalidTwoFactor
+		}
+
+		now := time.Now()
+		if err := tx.Model(&record).Updates(map[string]interface{}{"enabled_at": now, "last_used_step": step}).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+
+		var err error
+		codes, err = replaceRecoveryCodes(tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable은 현재 코드(또는 복구 코드)를 확인한 뒤 2단계 인증을 끕니다
+func (s *TwoFactorService) Disable(userID uint, code string) error {
+	if err := s.Verify(userID, code); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserTwoFactor{}).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return errors.ErrDatabaseError
+		}
+		return nil
+	})
+}
+
+// RegenerateRecoveryCodes는 현재 코드를 확인한 뒤 기존 복구 코드를 모두 폐기하고 새로 발급합니다
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	if err := s.Verify(userID, code); err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		codes, err = replaceRecoveryCodes(tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Verify는 TOTP 코드 또는 복구 코드를 확인합니다. 한 번 사용된 코드는 다시 사용할 수 없습니다
+func (s *TwoFactorService) Verify(userID uint, code string) error {
+	var record models.UserTwoFactor
+	if err := s.db.Where("user_id = ? AND enabled_at IS NOT NULL", userID).First(&record).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.ErrTwoFactorDisabled
+		}
+		return errors.ErrDatabaseError
+	}
+
+	if step, ok := totp.Validate(record.Secret, code, time.Now(), totpSkew); ok {
+		// 이미 사용된 시간 단계의 코드는 거부 (동시 요청도 하나만 성공)
+		result := s.db.Model(&models.UserTwoFactor{}).
+			Where("user_id = ? AND last_used_step < ?", userID, step).
+			Update("last_used_step", step)
+		if result.Error != nil {
+			return errors.ErrDatabaseError
+		}
+		if result.RowsAffected == 0 {
+			return errors.ErrInvalidTwoFactor
+		}
+		return nil
+	}
+
+	result := s.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hashToken(normalizeRecoveryCode(code))).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return errors.ErrDatabaseError
+	}
+	if result.RowsAffected == 0 {
+		return errors.ErrInvalidTwoFactor
+	}
+	return nil
+}
+
+// replaceRecoveryCodes는 사용자의 복구 코드를 새로 발급합니다. 평문은 이때 한 번만 반환됩니다
+func replaceRecoveryCodes(tx *gorm.DB, userID uint) ([]string, error) {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	records := make([]models.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		records = append(records, models.RecoveryCode{UserID: userID, CodeHash: hashToken(normalizeRecoveryCode(code))})
+	}
+
+	if err := tx.Create(&records).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+	return codes, nil
+}
+
+// newRecoveryCode는 "xxxxx-xxxxx" 형식의 복구 코드를 만듭니다 (약 49비트)
+func newRecoveryCode() (string, error) {
+	// 모듈로 편향이 없도록 알파벳 길이의 배수보다 큰 바이트는 버림
+	limit := byte(256 / len(recoveryCodeAlphabet) * len(recoveryCodeAlphabet))
+
+	var sb strings.Builder
+	buf := make([]byte, 16)
+	for n := 0; n < 10; {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, v := range buf {
+			if v >= limit || n == 10 {
+				continue
+			}
+			if n == 5 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(recoveryCodeAlphabet[int(v)%len(recoveryCodeAlphabet)])
+			n++
+		}
+	}
+	return sb.String(), nil
+}
+
+// normalizeRecoveryCode는 입력된 복구 코드에서 구분자와 공백을 없애고 소문자로 바꿉니다
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+// IssueWSTicket은 액세스 토큰으로 인증된 사용자에게 웹소켓 연결 티켓을 발급합니다.
+// 티켓은 요청한 클라이언트 IP에서 한 번만, 몇 초 안에만 사용할 수 있습니다
+func (s *AuthService) IssueWSTicket(claims *TokenClaims, ip string) (*dto.WSTicketResponse, error) {
+	ticket, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	record := models.WSTicket{
+		TicketHash:     hashToken(ticket),
+		UserID:         claims.UserID,
+		TokenID:        claims.TokenID,
+		SessionID:      claims.SessionID,
+		GuestRoomID:    claims.GuestRoomID,
+		Scopes:         strings.Join(claims.Scopes, ","),
+		TokenExpiresAt: claims.ExpiresAt,
+		IP:             ip,
+		ExpiresAt:      now.Add(s.wsTicketDuration),
+	}
+	if err := s.db.Create(&record).Error; err != nil {
+		return nil, errors.ErrDatabaseError
+	}
+
+	// 사용하지 않고 만료된 티켓 정리
+	if err := s.db.Where("expires_at < ?", now).Delete(&models.WSTicket{}).Error; err != nil {
+		log.Printf("Failed to purge expired websocket tickets: %v", err)
+	}
+
+	return &dto.WSTicketResponse{Ticket: ticket, ExpiresAt: record.ExpiresAt}, nil
+}
+
+// RedeemWSTicket은 티켓을 사용 처리하고, 티켓을 발급받은 토큰의 클레임을 반환합니다.
+// 다른 IP에서 제시된 티켓은 거부하며, 거부된 티켓도 다시 사용할 수 없습니다
+func (s *AuthService) RedeemWSTicket(ticket, ip string) (*TokenClaims, error) {
+	if ticket == "" {
+		return nil, errors.ErrInvalidToken
+	}
+
+	var record models.WSTicket
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("ticket_hash = ?", hashToken(ticket)).First(&record).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.ErrInvalidToken
+			}
+			return errors.ErrDatabaseError
+		}
+
+		result := tx.Where("ticket_hash = ?", record.TicketHash).Delete(&models.WSTicket{})
+		if result.Error != nil {
+			return errors.ErrDatabaseError
+		}
+		if result.RowsAffected == 0 {
+			// 동시에 들어온 다른 연결이 먼저 사용함
+			return errors.ErrInvalidToken
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(record.ExpiresAt) {
+		return nil, errors.ErrTokenExpired
+	}
+	if record.IP != ip {
+		return nil, errors.ErrInvalidToken
+	}
+
+	// 티켓 발급 후 원래 토큰이나 세션, 사용자의 모든 세션이 폐기됐는지 확인
+	claims := &TokenClaims{
+		UserID:       record.UserID,
+		TokenID:      record.TokenID,
+		SessionID:    record.SessionID,
+		IssuedAt:     record.CreatedAt.Unix(),
+		IssuedAtNano: record.CreatedAt.UnixNano(),
+		ExpiresAt:    record.TokenExpiresAt,
+		GuestRoomID:  record.GuestRoomID,
+		Scopes:       record.ScopeList(),
+	}
+	revoked, err := s.revocations.IsRevoked(claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, errors.ErrTokenRevoked
+	}
+	return claims, nil
+}
+
+// VerifyWSQueryToken은 폐기 예정인 ?token= 웹소켓 연결의 액세스 토큰을 검증합니다.
+// auth.allow_ws_query_token이 꺼져 있으면 항상 거부하며, 다음 릴리스에서 제거합니다
+func (s *AuthService) VerifyWSQueryToken(token string) (*TokenClaims, error) {
+	if !s.allowWSQueryToken {
+		return nil, errors.ErrInvalidToken
+	}
+	log.Printf("DEPRECATED: websocket connection authenticated with ?token=; request a ticket from /api/protected/ws-ticket instead")
+	return s.VerifyTokenClaims(token)
+}
@@ -8,10 +8,60 @@ import (
 
 // AutoMigrate는 모든 모델에 대한 데이터베이스 마이그레이션을 실행합니다
 func DBAutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	if err := db.AutoMigrate(
 		&models.User{},
 		&models.Message{},
 		&models.Room{},
 		&models.RoomUser{},
-	)
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.UserIdentity{},
+		&models.OIDCLoginState{},
+		&models.ActionToken{},
+		&models.UserTwoFactor{},
+		&models.RecoveryCode{},
+		&models.LoginAttempt{},
+		&models.APIKey{},
+		&models.WSTicket{},
+		&models.Session{},
+		&models.RoomShareLink{},
+		&models.RoomInvite{},
+		&models.RoomBan{},
+		&models.Presence{},
+	); err != nil {
+		return err
+	}
+
+	return migrateRoomRoles(db)
+}
+
+// migrateRoomRoles는 예전 is_admin, read_only 플래그를 채팅방 역할로 옮기고 플래그 컬럼을 지웁니다.
+// 관리자 플래그가 있던 채팅방 생성자는 owner, 나머지 관리자는 admin이 됩니다
+func migrateRoomRoles(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.RoomUser{}, "is_admin") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Migrator().HasColumn(&models.RoomUser{}, "read_only") {
+			if err := tx.Exec("UPDATE room_users SET role = ? WHERE read_only = ?", models.RoomRoleReadOnly, true).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("UPDATE room_users SET role = ? WHERE is_admin = ?", models.RoomRoleAdmin, true).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`UPDATE room_users SET role = ? WHERE is_admin = ? AND user_id =
+			(SELECT created_by FROM rooms WHERE rooms.id = room_users.room_id)`, models.RoomRoleOwner, true).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Migrator().DropColumn(&models.RoomUser{}, "is_admin"); err != nil {
+			return err
+		}
+		if tx.Migrator().HasColumn(&models.RoomUser{}, "read_only") {
+			return tx.Migrator().DropColumn(&models.RoomUser{}, "read_only")
+		}
+		return nil
+	})
 }
@@ -0,0 +1,226 @@
+package kafka
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBufferFull은 회로가 열린 상태에서 버퍼가 가득 차 메시지를 버렸을 때 반환됩니다
+var ErrBufferFull = errors.New("kafka: circuit open and buffer full, message dropped")
+
+// 회로 차단기 상태
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// BreakerOptions는 회로 차단기 설정입니다
+type BreakerOptions struct {
+	// FailureThreshold는 회로를 여는 연속 전송 실패 횟수입니다.
+	// 큐에 넣을 때의 오류와 브로커의 전송 결과(delivery report) 실패를 모두 셉니다
+	FailureThreshold int
+	// RetryInterval은 회로가 열린 동안 브로커 복구를 확인하는 주기입니다
+	RetryInterval time.Duration
+	// BufferSize는 회로가 열린 동안 보관할 최대 메시지 수입니다. 가득 차면 새 메시지를 버립니다
+	BufferSize int
+}
+
+func (o BreakerOptions) withDefaults() BreakerOptions {
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = 3
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = 5 * time.Second
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = 10000
+	}
+	return o
+}
+
+// brokerProducer는 회로 차단기가 감싸는 브로커 프로듀서입니다
+type brokerProducer interface {
+	ProduceWithKey(topic string, key, value []byte) error
+	Ping(timeout time.Duration) error
+	Close()
+}
+
+type bufferedMessage struct {
+	topic string
+	key   []byte
+	value []byte
+}
+
+// ResilientProducer는 브로커 발행을 회로 차단기로 감쌉니다.
+// 브로커를 사용할 수 없는 동안에는 메시지를 버퍼에 보관하고, 브로커가 복구되면 순서대로 다시 발행합니다
+type ResilientProducer struct {
+	inner   brokerProducer
+	metrics *Metrics
+	options BreakerOptions
+
+	mu      sync.Mutex
+	state   string
+	buffer  []bufferedMessage
+	dropped atomic.Uint64
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewResilientProducer는 회로 차단기가 적용된 프로듀서를 생성하고 복구 확인 루프를 시작합니다
+func NewResilientProducer(inner brokerProducer, metrics *Metrics, options BreakerOptions) *ResilientProducer {
+	p := &ResilientProducer{
+		inner:   inner,
+		metrics: metrics,
+		options: options.withDefaults(),
+		state:   CircuitClosed,
+		stop:    make(chan struct{}),
+	}
+
+	go p.runRecovery()
+
+	return p
+}
+
+// Produce는 지정된 토픽에 메시지를 발행합니다
+func (p *ResilientProducer) Produce(topic string, value []byte) error {
+	return p.ProduceWithKey(topic, nil, value)
+}
+
+// ProduceWithKey는 회로가 닫혀 있으면 바로 발행하고, 열려 있으면 버퍼에 보관합니다.
+// 버퍼에 보관된 경우에도 nil을 반환하며, 버퍼가 가득 차 버려진 경우에만 ErrBufferFull을 반환합니다
+func (p *ResilientProducer) ProduceWithKey(topic string, key, value []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state == CircuitClosed {
+		if p.metrics.brokerDown() {
+			p.openLocked("broker unreachable")
+		} else if p.metrics.deliveryFailureStreak() >= p.options.FailureThreshold {
+			p.openLocked("too many failed deliveries")
+		}
+	}
+
+	if p.state != CircuitClosed {
+		return p.bufferLocked(topic, key, value)
+	}
+
+	if err := p.inner.ProduceWithKey(topic, key, value); err != nil {
+		p.metrics.deliveryFailed()
+		if p.metrics.deliveryFailureStreak() < p.options.FailureThreshold {
+			return err
+		}
+		// 회로를 여는 메시지는 잃어버리지 않도록 버퍼에 보관
+		p.openLocked(err.Error())
+		return p.bufferLocked(topic, key, value)
+	}
+
+	return nil
+}
+
+// State는 현재 회로 상태와 버퍼에 보관된 메시지 수를 반환합니다
+func (p *ResilientProducer) State() (string, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state, len(p.buffer)
+}
+
+// Dropped는 버퍼가 가득 차 버려진 메시지 수를 반환합니다
+func (p *ResilientProducer) Dropped() uint64 {
+	return p.dropped.Load()
+}
+
+// Close는 복구 루프를 멈추고 내부 프로듀서를 닫습니다
+func (p *ResilientProducer) Close() {
+	p.once.Do(func() {
+		close(p.stop)
+	})
+
+	p.mu.Lock()
+	if n := len(p.buffer); n > 0 {
+		log.Printf("Kafka producer closing with %d buffered messages not delivered", n)
+	}
+	p.mu.Unlock()
+
+	p.inner.Close()
+}
+
+func (p *ResilientProducer) openLocked(reason string) {
+	if p.state != CircuitOpen {
+		log.Printf("Kafka circuit opened, switching to local-only delivery: %s", reason)
+	}
+	p.state = CircuitOpen
+}
+
+func (p *ResilientProducer) bufferLocked(topic string, key, value []byte) error {
+	if len(p.buffer) >= p.options.BufferSize {
+		p.dropped.Add(1)
+		return ErrBufferFull
+	}
+	p.buffer = append(p.buffer, bufferedMessage{topic: topic, key: key, value: value})
+	return nil
+}
+
+// runRecovery는 회로가 열려 있는 동안 주기적으로 브로커를 확인하고, 복구되면 버퍼를 비웁니다
+func (p *ResilientProducer) runRecovery() {
+	ticker := time.NewTicker(p.options.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		open := p.state == CircuitOpen
+		p.mu.Unlock()
+		if !open {
+			continue
+		}
+
+		if err := p.inner.Ping(p.options.RetryInterval); err != nil {
+			continue
+		}
+
+		p.mu.Lock()
+		p.state = CircuitHalfOpen
+		p.mu.Unlock()
+
+		p.flush()
+	}
+}
+
+// flush는 버퍼의 메시지를 순서대로 발행합니다. 모두 발행하면 회로를 닫고, 실패하면 다시 엽니다
+func (p *ResilientProducer) flush() {
+	flushed := 0
+	for {
+		p.mu.Lock()
+		if len(p.buffer) == 0 {
+			p.state = CircuitClosed
+			p.metrics.resetDeliveryFailures()
+			p.mu.Unlock()
+			log.Printf("Kafka circuit closed, %d buffered messages delivered", flushed)
+			return
+		}
+		msg := p.buffer[0]
+		p.mu.Unlock()
+
+		if err := p.inner.ProduceWithKey(msg.topic, msg.key, msg.value); err != nil {
+			p.mu.Lock()
+			p.openLocked(err.Error())
+			p.mu.Unlock()
+			return
+		}
+
+		p.mu.Lock()
+		p.buffer = p.buffer[1:]
+		p.mu.Unlock()
+		flushed++
+	}
+}
@@ -0,0 +1,185 @@
+package kafka
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBroker는 발행 결과와 Ping 결과를 조절할 수 있는 brokerProducer입니다
+type fakeBroker struct {
+	mu         sync.Mutex
+	produced   []string
+	produceErr error
+	pingErr    error
+}
+
+func (f *fakeBroker) ProduceWithKey(topic string, key, value []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.produceErr != nil {
+		return f.produceErr
+	}
+	f.produced = append(f.produced, string(value))
+	return nil
+}
+
+func (f *fakeBroker) Ping(timeout time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pingErr
+}
+
+func (f *fakeBroker) Close() {}
+
+func (f *fakeBroker) set(produceErr, pingErr error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.produceErr = produceErr
+	f.pingErr = pingErr
+}
+
+func (f *fakeBroker) messages() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.produced...)
+}
+
+func newTestBreaker(t *testing.T, broker *fakeBroker, metrics *Metrics, options BreakerOptions) *ResilientProducer {
+	t.Helper()
+	p := NewResilientProducer(broker, metrics, options)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func TestBreakerStaysClosedBeforeFirstPing(t *testing.T) {
+	broker := &fakeBroker{}
+	p := newTestBreaker(t, broker, NewMetrics(), BreakerOptions{RetryInterval: time.Hour})
+
+	// 브로커 상태를 아직 모르면 장애로 보지 않고 바로 발행
+	require.NoError(t, p.Produce("topic", []byte("m1")))
+
+	state, buffered := p.State()
+	assert.Equal(t, CircuitClosed, state)
+	assert.Zero(t, buffered)
+	assert.Equal(t, []string{"m1"}, broker.messages())
+}
+
+func TestBreakerOpensWhenBrokerUnreachable(t *testing.T) {
+	broker := &fakeBroker{}
+	metrics := NewMetrics()
+	metrics.brokerUnreachable(errors.New("all brokers down"))
+	p := newTestBreaker(t, broker, metrics, BreakerOptions{RetryInterval: time.Hour})
+
+	require.NoError(t, p.Produce("topic", []byte("m1")))
+
+	state, buffered := p.State()
+	assert.Equal(t, CircuitOpen, state)
+	assert.Equal(t, 1, buffered)
+	assert.Empty(t, broker.messages())
+}
+
+func TestBreakerOpensAfterFailureThreshold(t *testing.T) {
+	tests := []struct {
+		name string
+		// fail은 threshold만큼 실패를 일으킵니다
+		fail func(p *ResilientProducer, broker *fakeBroker, metrics *Metrics) error
+	}{
+		{
+			name: "enqueue errors",
+			fail: func(p *ResilientProducer, broker *fakeBroker, metrics *Metrics) error {
+				broker.set(errors.New("queue full"), nil)
+				defer broker.set(nil, nil)
+				var last error
+				for i := 0; i < 3; i++ {
+					last = p.Produce("topic", []byte("failed"))
+				}
+				return last
+			},
+		},
+		{
+			name: "delivery reports",
+			fail: func(p *ResilientProducer, broker *fakeBroker, metrics *Metrics) error {
+				for i := 0; i < 3; i++ {
+					metrics.deliveryFailed()
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := &fakeBroker{}
+			metrics := NewMetrics()
+			p := newTestBreaker(t, broker, metrics, BreakerOptions{FailureThreshold: 3, RetryInterval: time.Hour})
+
+			// 회로를 여는 메시지는 버퍼에 보관되므로 오류가 아님
+			require.NoError(t, tt.fail(p, broker, metrics))
+			require.NoError(t, p.Produce("topic", []byte("buffered")))
+
+			state, _ := p.State()
+			assert.Equal(t, CircuitOpen, state)
+			assert.NotContains(t, broker.messages(), "buffered")
+		})
+	}
+}
+
+func TestBreakerResetsFailuresOnSuccessfulDelivery(t *testing.T) {
+	broker := &fakeBroker{}
+	metrics := NewMetrics()
+	p := newTestBreaker(t, broker, metrics, BreakerOptions{FailureThreshold: 3, RetryInterval: time.Hour})
+
+	metrics.deliveryFailed()
+	metrics.deliveryFailed()
+	metrics.deliverySucceeded()
+	metrics.deliveryFailed()
+
+	require.NoError(t, p.Produce("topic", []byte("m1")))
+	state, _ := p.State()
+	assert.Equal(t, CircuitClosed, state)
+}
+
+func TestBreakerFlushesBufferInOrderWhenBrokerRecovers(t *testing.T) {
+	broker := &fakeBroker{}
+	broker.set(nil, errors.New("still down"))
+	metrics := NewMetrics()
+	metrics.brokerUnreachable(errors.New("all brokers down"))
+	p := newTestBreaker(t, broker, metrics, BreakerOptions{RetryInterval: 10 * time.Millisecond})
+
+	for _, m := range []string{"m1", "m2", "m3"} {
+		require.NoError(t, p.Produce("topic", []byte(m)))
+	}
+	assert.Empty(t, broker.messages())
+
+	// 브로커 복구 후 복구 루프가 버퍼를 순서대로 발행하고 회로를 닫음
+	broker.set(nil, nil)
+	metrics.brokerReachable()
+	require.Eventually(t, func() bool {
+		state, buffered := p.State()
+		return state == CircuitClosed && buffered == 0
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"m1", "m2", "m3"}, broker.messages())
+
+	require.NoError(t, p.Produce("topic", []byte("m4")))
+	assert.Equal(t, []string{"m1", "m2", "m3", "m4"}, broker.messages())
+}
+
+func TestBreakerDropsWhenBufferFull(t *testing.T) {
+	broker := &fakeBroker{}
+	metrics := NewMetrics()
+	metrics.brokerUnreachable(errors.New("all brokers down"))
+	p := newTestBreaker(t, broker, metrics, BreakerOptions{BufferSize: 2, RetryInterval: time.Hour})
+
+	require.NoError(t, p.Produce("topic", []byte("m1")))
+	require.NoError(t, p.Produce("topic", []byte("m2")))
+	assert.ErrorIs(t, p.Produce("topic", []byte("m3")), ErrBufferFull)
+
+	_, buffered := p.State()
+	assert.Equal(t, 2, buffered)
+	assert.Equal(t, uint64(1), p.Dropped())
+}
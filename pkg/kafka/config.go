@@ -0,0 +1,171 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"mult-working/internal/config"
+)
+
+// 지원하는 보안 프로토콜
+const (
+	SecurityProtocolPlaintext     = "plaintext"
+	SecurityProtocolSSL           = "ssl"
+	SecurityProtocolSASLPlaintext = "sasl_plaintext"
+	SecurityProtocolSASLSSL       = "sasl_ssl"
+)
+
+var supportedSASLMechanisms = map[string]bool{
+	"PLAIN":         true,
+	"SCRAM-SHA-256": true,
+	"SCRAM-SHA-512": true,
+}
+
+// reservedProperties는 애플리케이션이 직접 관리하거나 타입이 있는 설정에서 채우므로 properties로 덮어쓸 수 없는 설정입니다
+var reservedProperties = map[string]bool{
+	"bootstrap.servers":                   true,
+	"group.id":                            true,
+	"enable.auto.commit":                  true,
+	"client.id":                           true,
+	"security.protocol":                   true,
+	"sasl.mechanism":                      true,
+	"sasl.mechanisms":                     true, // sasl.mechanism의 librdkafka 별칭
+	"sasl.username":                       true,
+	"sasl.password":                       true,
+	"ssl.ca.location":                     true,
+	"ssl.certificate.location":            true,
+	"ssl.key.location":                    true,
+	"ssl.key.password":                    true,
+	"enable.ssl.certificate.verification": true,
+}
+
+// newConfigMap은 KafkaConfig를 검증하고 프로듀서/컨슈머 공통 librdkafka 설정을 만듭니다
+func newConfigMap(cfg config.KafkaConfig) (kafka.ConfigMap, error) {
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	protocol := strings.ToLower(cfg.SecurityProtocol)
+	if protocol == "" {
+		protocol = SecurityProtocolPlaintext
+	}
+
+	cm := kafka.ConfigMap{
+		"bootstrap.servers": strings.Join(cfg.Brokers, ","),
+		"security.protocol": protocol,
+	}
+
+	if cfg.ClientID != "" {
+		cm["client.id"] = cfg.ClientID
+	}
+
+	if protocol == SecurityProtocolSASLPlaintext || protocol == SecurityProtocolSASLSSL {
+		cm["sasl.mechanism"] = strings.ToUpper(cfg.SASL.Mechanism)
+		cm["sasl.username"] = cfg.SASL.Username
+		cm["sasl.password"] = cfg.SASL.Password
+	}
+
+	if protocol == SecurityProtocolSSL || protocol == SecurityProtocolSASLSSL {
+		if cfg.TLS.CALocation != "" {
+			cm["ssl.ca.location"] = cfg.TLS.CALocation
+		}
+		if cfg.TLS.CertLocation != "" {
+			cm["ssl.certificate.location"] = cfg.TLS.CertLocation
+			cm["ssl.key.location"] = cfg.TLS.KeyLocation
+		}
+		if cfg.TLS.KeyPassword != "" {
+			cm["ssl.key.password"] = cfg.TLS.KeyPassword
+		}
+		if cfg.TLS.InsecureSkipVerify {
+			cm["enable.ssl.certificate.verification"] = false
+		}
+	}
+
+	for key, value := range flattenProperties("", cfg.Properties) {
+		cm[key] = value
+	}
+
+	return cm, nil
+}
+
+// ValidateConfig는 Kafka 연결 설정의 오류를 모두 모아 반환합니다
+func ValidateConfig(cfg config.KafkaConfig) error {
+	var errs []error
+
+	if len(cfg.Brokers) == 0 {
+		errs = append(errs, errors.New("kafka.brokers: at least one broker is required"))
+	}
+	for i, broker := range cfg.Brokers {
+		if strings.TrimSpace(broker) == "" {
+			errs = append(errs, fmt.Errorf("kafka.brokers[%d]: empty broker address", i))
+		}
+	}
+
+	protocol := strings.ToLower(cfg.SecurityProtocol)
+	switch protocol {
+	case "", SecurityProtocolPlaintext, SecurityProtocolSSL:
+	case SecurityProtocolSASLPlaintext, SecurityProtocolSASLSSL:
+		if !supportedSASLMechanisms[strings.ToUpper(cfg.SASL.Mechanism)] {
+			errs = append(errs, fmt.Errorf("kafka.sasl.mechanism: %q is not supported (use PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512)", cfg.SASL.Mechanism))
+		}
+		if cfg.SASL.Username == "" || cfg.SASL.Password == "" {
+			errs = append(errs, fmt.Errorf("kafka.sasl: username and password are required for %s", protocol))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("kafka.security_protocol: %q is not supported (use plaintext, ssl, sasl_plaintext or sasl_ssl)", cfg.SecurityProtocol))
+	}
+
+	if (cfg.TLS.CertLocation == "") != (cfg.TLS.KeyLocation == "") {
+		errs = append(errs, errors.New("kafka.tls: cert_location and key_location must be set together"))
+	}
+	if cfg.TLS.CALocation != "" || cfg.TLS.CertLocation != "" {
+		if protocol != SecurityProtocolSSL && protocol != SecurityProtocolSASLSSL {
+			errs = append(errs, fmt.Errorf("kafka.tls: certificates are configured but security_protocol is %q", protocol))
+		}
+	}
+	for name, path := range map[string]string{
+		"ca_location":   cfg.TLS.CALocation,
+		"cert_location": cfg.TLS.CertLocation,
+		"key_location":  cfg.TLS.KeyLocation,
+	} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			errs = append(errs, fmt.Errorf("kafka.tls.%s: %w", name, err))
+		}
+	}
+
+	for key := range flattenProperties("", cfg.Properties) {
+		if reservedProperties[strings.ToLower(key)] {
+			errs = append(errs, fmt.Errorf("kafka.properties: %q is managed by the application and cannot be overridden", key))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid kafka configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// flattenProperties는 viper가 '.' 기준으로 중첩시킨 키를 librdkafka 속성 이름으로 되돌립니다
+func flattenProperties(prefix string, props map[string]interface{}) map[string]kafka.ConfigValue {
+	flat := make(map[string]kafka.ConfigValue)
+	for key, value := range props {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			for k, v := range flattenProperties(name, nested) {
+				flat[k] = v
+			}
+			continue
+		}
+		flat[name] = fmt.Sprint(value)
+	}
+	return flat
+}
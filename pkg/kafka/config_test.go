@@ -0,0 +1,62 @@
+package kafka
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/config"
+)
+
+func TestValidateConfigAcceptsValidConfig(t *testing.T) {
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(ca, []byte("ca"), 0o600))
+
+	err := ValidateConfig(config.KafkaConfig{
+		Brokers:          []string{"kafka-1:9093", "kafka-2:9093"},
+		SecurityProtocol: "SASL_SSL",
+		SASL:             config.KafkaSASLConfig{Mechanism: "scram-sha-512", Username: "chat", Password: "secret"},
+		TLS:              config.KafkaTLSConfig{CALocation: ca},
+		Properties:       map[string]interface{}{"socket": map[string]interface{}{"keepalive": map[string]interface{}{"enable": true}}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestValidateConfigAggregatesErrors(t *testing.T) {
+	err := ValidateConfig(config.KafkaConfig{
+		Brokers:          []string{"kafka-1:9092", " "},
+		SecurityProtocol: "sasl_plaintext",
+		SASL:             config.KafkaSASLConfig{Mechanism: "GSSAPI"},
+		TLS:              config.KafkaTLSConfig{CertLocation: "/nonexistent/client.pem"},
+		Properties: map[string]interface{}{
+			"group":    map[string]interface{}{"id": "other"},
+			"security": map[string]interface{}{"protocol": "plaintext"},
+			"sasl":     map[string]interface{}{"password": "override", "mechanisms": "PLAIN"},
+			"ssl":      map[string]interface{}{"key": map[string]interface{}{"location": "/tmp/key.pem"}},
+		},
+	})
+	require.Error(t, err)
+
+	// 첫 오류에서 멈추지 않고 모든 오류를 한 번에 보고
+	msg := err.Error()
+	for _, want := range []string{
+		"invalid kafka configuration",
+		"kafka.brokers[1]: empty broker address",
+		`kafka.sasl.mechanism: "GSSAPI" is not supported`,
+		"kafka.sasl: username and password are required for sasl_plaintext",
+		"kafka.tls: cert_location and key_location must be set together",
+		`kafka.tls: certificates are configured but security_protocol is "sasl_plaintext"`,
+		"kafka.tls.cert_location:",
+		`kafka.properties: "group.id" is managed by the application`,
+		`kafka.properties: "security.protocol" is managed by the application`,
+		`kafka.properties: "sasl.password" is managed by the application`,
+		`kafka.properties: "sasl.mechanisms" is managed by the application`,
+		`kafka.properties: "ssl.key.location" is managed by the application`,
+	} {
+		assert.Contains(t, msg, want)
+	}
+	assert.Equal(t, 11, strings.Count(msg, "\n")+1)
+}
@@ -1,103 +1,526 @@
 package kafka
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"hash/fnv"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"mult-working/internal/config"
 )
 
-// MessageHandler는 Kafka 메시지를 처리하는 콜백 함수 타입입니다
+const (
+	// pollTimeoutMs는 Poll 한 번에 대기하는 최대 시간입니다. Stop 요청에 반응하는 주기이기도 합니다
+	pollTimeoutMs = 100
+	// workerQueueSize는 워커별 대기 메시지 버퍼 크기입니다
+	workerQueueSize = 64
+)
+
+// ErrConsumerRunning은 이미 실행 중인 컨슈머를 다시 시작하려 할 때 반환됩니다
+var ErrConsumerRunning = errors.New("kafka: consumer already running")
+
+// DeadLetterHandler는 재시도를 모두 실패한 메시지를 넘겨받습니다 (별도 토픽에 보관, 기록 후 건너뛰기 등).
+// nil을 반환하면 메시지를 처리한 것으로 보고 오프셋을 커밋합니다
+type DeadLetterHandler func(msg *kafka.Message, err error) error
+
+// ConsumerOptions는 컨슈머의 처리 방식을 설정합니다
+type ConsumerOptions struct {
+	// Concurrency는 동시에 메시지를 처리하는 워커 수입니다. 같은 키의 메시지는 항상 같은 워커가 순서대로 처리합니다
+	Concurrency int
+	// RetryPolicy는 핸들러 실패 시 재시도 정책입니다
+	RetryPolicy RetryPolicy
+	// DeadLetter는 RetryPolicy가 재시도를 멈춘 메시지를 넘겨받습니다.
+	// 설정하지 않았거나 실패하면 메시지의 오프셋을 커밋하지 않고 파티션 소비를 멈추며, 재시작이나 리밸런스 후 다시 전달됩니다
+	DeadLetter DeadLetterHandler
+	// CommitInterval은 처리 완료된 오프셋을 커밋하는 주기입니다
+	CommitInterval time.Duration
+	// Metrics는 처리량, 지연 시간, 핸들러 에러를 기록할 곳입니다
+	Metrics *Metrics
+}
+
+func (o ConsumerOptions) withDefaults() ConsumerOptions {
+	if o.Concurrency <= 0 {
+		o.Concurrency = 1
+	}
+	if o.RetryPolicy == nil {
+		o.RetryPolicy = NoRetry{}
+	}
+	if o.CommitInterval <= 0 {
+		o.CommitInterval = time.Second
+	}
+	return o
+}
 
 // Consumer는 Kafka 메시지 컨슈머를 래핑합니다
 type ConsumerImpl struct {
 	consumer *kafka.Consumer
-	handlers []MessageHandler
 	topic    string
+	options  ConsumerOptions
+	offsets  *offsetTracker
+	// commitOffsets는 오프셋을 브로커에 커밋합니다 (테스트에서 대체 가능)
+	commitOffsets func([]kafka.TopicPartition) ([]kafka.TopicPartition, error)
+	// pausePartitions는 파티션 소비를 멈춥니다 (테스트에서 대체 가능)
+	pausePartitions func([]kafka.TopicPartition) error
+
+	mu       sync.Mutex
+	handlers []MessageHandler
 	running  bool
+	cancel   context.CancelFunc
+	done     chan struct{}
+	// interrupt는 Stop이 호출되면 닫혀 진행 중인 Consume을 깨웁니다
+	interrupt chan struct{}
 }
 
 // NewConsumer는 새 Kafka 컨슈머를 생성합니다
-func NewConsumer(topic, groupID string, bootstrapServers []string) (*ConsumerImpl, error) {
-	c, err := kafka.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers":  bootstrapServers[0],
-		"group.id":           groupID,
-		"auto.offset.reset":  "latest",
-		"enable.auto.commit": true,
-	})
+func NewConsumer(cfg config.KafkaConfig, topic, groupID string, options ConsumerOptions) (*ConsumerImpl, error) {
+	cm, err := newConfigMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cm["group.id"] = groupID
+	cm["enable.auto.commit"] = false
+	if _, ok := cm["auto.offset.reset"]; !ok {
+		cm["auto.offset.reset"] = "latest"
+	}
 
+	c, err := kafka.NewConsumer(&cm)
 	if err != nil {
 		return nil, err
 	}
 
-	err = c.SubscribeTopics([]string{topic}, nil)
+	consumer := &ConsumerImpl{
+		consumer:        c,
+		handlers:        []MessageHandler{},
+		topic:           topic,
+		options:         options.withDefaults(),
+		offsets:         newOffsetTracker(),
+		commitOffsets:   c.CommitOffsets,
+		pausePartitions: c.Pause,
+		interrupt:       make(chan struct{}),
+	}
+
+	err = c.SubscribeTopics([]string{topic}, consumer.onRebalance)
 	if err != nil {
 		c.Close()
 		return nil, err
 	}
 
-	return &ConsumerImpl{
-		consumer: c,
-		handlers: []MessageHandler{},
-		topic:    topic,
-		running:  false,
-	}, nil
+	return consumer, nil
 }
 
-// AddHandler는 메시지 처리 핸들러를 추가합니다
+// AddHandler는 메시지 처리 핸들러를 추가합니다. Start 이후에 추가된 핸들러는 다음 Start부터 적용됩니다
 func (c *ConsumerImpl) AddHandler(handler MessageHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.handlers = append(c.handlers, handler)
 }
 
-// Start는 백그라운드에서 메시지 소비를 시작합니다
-func (c *ConsumerImpl) Start() {
+// Start는 백그라운드에서 메시지 소비를 시작합니다.
+// ctx는 시작 단계에서만 사용되며, 소비는 Stop이 호출될 때까지 계속됩니다
+func (c *ConsumerImpl) Start(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.running {
-		return
+		return ErrConsumerRunning
 	}
 
+	runCtx, cancel := context.WithCancel(context.Background())
 	c.running = true
+	c.cancel = cancel
+	c.done = make(chan struct{})
 
-	// 백그라운드에서 메시지 리스닝
-	go func() {
-		for c.running {
-			// Kafka에서 메시지 수신
-			msg, err := c.Consume()
-			if err != nil {
-				log.Printf("Error consuming message: %v", err)
-				time.Sleep(1 * time.Second)
-				continue
-			}
+	handlers := append([]MessageHandler(nil), c.handlers...)
+	go c.run(runCtx, handlers, c.done)
+
+	return nil
+}
+
+// Stop은 메시지 소비를 중지하고, 처리 중인 메시지가 끝나면 완료된 오프셋을 커밋합니다.
+// ctx가 먼저 만료되면 ctx의 에러를 반환합니다
+func (c *ConsumerImpl) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	// 동기 Consume 호출도 함께 중단
+	close(c.interrupt)
+	c.interrupt = make(chan struct{})
+	if !c.running {
+		c.mu.Unlock()
+		return nil
+	}
+	c.running = false
+	cancel, done := c.cancel, c.done
+	c.mu.Unlock()
 
-			// 등록된 모든 핸들러에게 메시지 전달
-			for _, handler := range c.handlers {
-				if err := handler(msg); err != nil {
-					log.Printf("Error handling message: %v", err)
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Consume는 메시지 하나를 동기적으로 소비하고 해당 오프셋을 커밋합니다.
+// 메시지를 기다리는 동안 ctx가 만료되면 ctx의 에러를, Stop이 호출되면 context.Canceled를 반환합니다
+func (c *ConsumerImpl) Consume(ctx context.Context) ([]byte, error) {
+	c.mu.Lock()
+	interrupt := c.interrupt
+	c.mu.Unlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-interrupt:
+			return nil, context.Canceled
+		default:
+		}
+
+		msg, err := c.consumer.ReadMessage(pollTimeoutMs * time.Millisecond)
+		if err != nil {
+			var kerr kafka.Error
+			if !errors.As(err, &kerr) || kerr.IsFatal() {
+				return nil, err
+			}
+			// 타임아웃과 일시적인 브로커 오류는 중단 여부를 확인하며 계속 대기
+			if kerr.Code() != kafka.ErrTimedOut {
+				log.Printf("Error consuming message: %v", kerr)
+				if kerr.Code() == kafka.ErrAllBrokersDown {
+					c.options.Metrics.brokerUnreachable(kerr)
 				}
 			}
+			continue
+		}
+
+		if _, err := c.consumer.CommitMessage(msg); err != nil {
+			return nil, err
 		}
-	}()
 
+		return msg.Value, nil
+	}
 }
 
-// Stop은 메시지 소비를 중지합니다
-func (c *ConsumerImpl) Stop() {
-	c.running = false
+// Close는 컨슈머를 닫습니다
+func (c *ConsumerImpl) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Stop(ctx); err != nil {
+		log.Printf("Error stopping consumer: %v", err)
+	}
+	c.consumer.Close()
 }
 
-// Consume는 메시지를 소비합니다
-func (c *ConsumerImpl) Consume() ([]byte, error) {
-	msg, err := c.consumer.ReadMessage(-1)
+// Lag는 할당된 파티션별로 현재 위치와 high watermark의 차이를 반환합니다
+func (c *ConsumerImpl) Lag() []PartitionLag {
+	assigned, err := c.consumer.Assignment()
+	if err != nil || len(assigned) == 0 {
+		return nil
+	}
+
+	positions, err := c.consumer.Position(assigned)
 	if err != nil {
-		return nil, err
+		return nil
 	}
-	fmt.Println("Received message", string(msg.Value))
 
-	return msg.Value, nil
+	lags := make([]PartitionLag, 0, len(positions))
+	for _, tp := range positions {
+		if tp.Topic == nil || tp.Offset < 0 {
+			// 아직 소비를 시작하지 않은 파티션
+			continue
+		}
+		_, high, err := c.consumer.GetWatermarkOffsets(*tp.Topic, tp.Partition)
+		if err != nil || high < 0 {
+			continue
+		}
+		lag := high - int64(tp.Offset)
+		if lag < 0 {
+			lag = 0
+		}
+		lags = append(lags, PartitionLag{Topic: *tp.Topic, Partition: tp.Partition, Lag: lag})
+	}
+	return lags
 }
 
-// Close는 컨슈머를 닫습니다
-func (c *ConsumerImpl) Close() {
-	c.Stop()
-	c.consumer.Close()
+// run은 Poll 루프를 실행하며 메시지를 키 기준으로 워커에 분배합니다
+func (c *ConsumerImpl) run(ctx context.Context, handlers []MessageHandler, done chan struct{}) {
+	defer close(done)
+
+	var wg sync.WaitGroup
+	workers := make([]chan *kafka.Message, c.options.Concurrency)
+	for i := range workers {
+		workers[i] = make(chan *kafka.Message, workerQueueSize)
+		wg.Add(1)
+		go func(queue <-chan *kafka.Message) {
+			defer wg.Done()
+			for msg := range queue {
+				c.process(ctx, handlers, msg)
+			}
+		}(workers[i])
+	}
+
+	ticker := time.NewTicker(c.options.CommitInterval)
+	defer ticker.Stop()
+
+poll:
+	for {
+		select {
+		case <-ctx.Done():
+			break poll
+		case <-ticker.C:
+			c.commit(nil)
+		default:
+		}
+
+		switch ev := c.consumer.Poll(pollTimeoutMs).(type) {
+		case *kafka.Message:
+			c.offsets.track(ev.TopicPartition)
+			select {
+			case workers[c.workerIndex(ev)] <- ev:
+			case <-ctx.Done():
+				// 분배하지 못한 메시지는 커밋되지 않으므로 다음 소비 시 다시 전달됩니다
+				break poll
+			}
+		case kafka.Error:
+			log.Printf("Error consuming message: %v", ev)
+			if ev.Code() == kafka.ErrAllBrokersDown {
+				c.options.Metrics.brokerUnreachable(ev)
+			}
+		}
+	}
+
+	for _, queue := range workers {
+		close(queue)
+	}
+	wg.Wait()
+	c.commit(nil)
+}
+
+// process는 등록된 모든 핸들러에게 메시지를 전달하고, 모두 성공하면 오프셋을 완료로 표시합니다.
+// 재시도를 모두 실패한 메시지는 DeadLetter가 받아야만 완료로 표시합니다
+func (c *ConsumerImpl) process(ctx context.Context, handlers []MessageHandler, msg *kafka.Message) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	start := time.Now()
+	var failure error
+	for _, handler := range handlers {
+		if err := c.handleWithRetry(ctx, handler, msg.Value); err != nil {
+			if ctx.Err() != nil {
+				// 중지 중에 중단된 메시지는 커밋하지 않고 다시 전달되도록 둡니다
+				return
+			}
+			failure = err
+		}
+	}
+
+	if failure != nil && !c.deadLetter(msg, failure) {
+		return
+	}
+
+	c.offsets.done(msg.TopicPartition)
+	c.options.Metrics.observeProcessing(time.Since(start))
+}
+
+// deadLetter는 실패한 메시지를 DeadLetter에 넘기고, 넘기지 못하면 파티션을 멈춥니다.
+// 멈춘 파티션은 실패한 오프셋 이후로 커밋되지 않으므로 재시작이나 리밸런스 후 그 메시지부터 다시 전달됩니다
+func (c *ConsumerImpl) deadLetter(msg *kafka.Message, failure error) bool {
+	if c.options.DeadLetter != nil {
+		err := c.options.DeadLetter(msg, failure)
+		if err == nil {
+			log.Printf("Message %v failed and was dead-lettered: %v", msg.TopicPartition, failure)
+			return true
+		}
+		log.Printf("Failed to dead-letter message %v: %v", msg.TopicPartition, err)
+	}
+
+	log.Printf("Error handling message %v, pausing partition until restart or rebalance: %v", msg.TopicPartition, failure)
+	if err := c.pausePartitions([]kafka.TopicPartition{msg.TopicPartition}); err != nil {
+		log.Printf("Failed to pause partition %v: %v", msg.TopicPartition, err)
+	}
+	return false
+}
+
+// handleWithRetry는 RetryPolicy에 따라 핸들러를 재시도합니다
+func (c *ConsumerImpl) handleWithRetry(ctx context.Context, handler MessageHandler, value []byte) error {
+	for attempt := 1; ; attempt++ {
+		err := handler(value)
+		if err == nil {
+			return nil
+		}
+		c.options.Metrics.handlerFailed()
+
+		delay, retry := c.options.RetryPolicy.Backoff(attempt, err)
+		if !retry {
+			return err
+		}
+		log.Printf("Error handling message (attempt %d), retrying in %v: %v", attempt, delay, err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// workerIndex는 메시지 키(없으면 파티션)를 기준으로 워커를 선택해 키별 순서를 보장합니다
+func (c *ConsumerImpl) workerIndex(msg *kafka.Message) int {
+	if len(msg.Key) == 0 {
+		return int(msg.TopicPartition.Partition) % c.options.Concurrency
+	}
+	h := fnv.New32a()
+	h.Write(msg.Key)
+	return int(h.Sum32() % uint32(c.options.Concurrency))
+}
+
+// commit은 연속으로 처리 완료된 오프셋까지 커밋합니다. partitions가 nil이면 모든 파티션을 대상으로 합니다
+func (c *ConsumerImpl) commit(partitions []kafka.TopicPartition) {
+	offsets := c.offsets.committable(partitions)
+	if len(offsets) == 0 {
+		return
+	}
+
+	if _, err := c.commitOffsets(offsets); err != nil {
+		log.Printf("Error committing offsets: %v", err)
+		return
+	}
+	c.offsets.committed(offsets)
+}
+
+// onRebalance는 파티션이 회수되기 전에 처리 완료된 오프셋을 커밋합니다
+func (c *ConsumerImpl) onRebalance(_ *kafka.Consumer, ev kafka.Event) error {
+	if revoked, ok := ev.(kafka.RevokedPartitions); ok {
+		c.commit(revoked.Partitions)
+		c.offsets.remove(revoked.Partitions)
+	}
+	return nil
+}
+
+// offsetTracker는 파티션별로 처리 중인 오프셋을 추적해 커밋 가능한 위치를 계산합니다
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[partitionKey]*partitionOffsets
+}
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+type partitionOffsets struct {
+	inflight  []kafka.Offset
+	done      map[kafka.Offset]bool
+	next      kafka.Offset
+	committed kafka.Offset
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[partitionKey]*partitionOffsets)}
+}
+
+func keyOf(tp kafka.TopicPartition) partitionKey {
+	key := partitionKey{partition: tp.Partition}
+	if tp.Topic != nil {
+		key.topic = *tp.Topic
+	}
+	return key
+}
+
+// track은 분배된 메시지의 오프셋을 처리 중으로 기록합니다
+func (t *offsetTracker) track(tp kafka.TopicPartition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := keyOf(tp)
+	p, ok := t.partitions[key]
+	if !ok {
+		p = &partitionOffsets{
+			done:      make(map[kafka.Offset]bool),
+			next:      kafka.OffsetInvalid,
+			committed: kafka.OffsetInvalid,
+		}
+		t.partitions[key] = p
+	}
+	p.inflight = append(p.inflight, tp.Offset)
+}
+
+// done은 오프셋의 처리 완료를 기록하고, 앞에서부터 연속으로 완료된 오프셋을 정리합니다
+func (t *offsetTracker) done(tp kafka.TopicPartition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[keyOf(tp)]
+	if !ok {
+		// 이미 회수된 파티션
+		return
+	}
+
+	p.done[tp.Offset] = true
+	for len(p.inflight) > 0 && p.done[p.inflight[0]] {
+		delete(p.done, p.inflight[0])
+		p.next = p.inflight[0] + 1
+		p.inflight = p.inflight[1:]
+	}
+}
+
+// committable은 아직 커밋되지 않은 커밋 가능 오프셋 목록을 반환합니다
+func (t *offsetTracker) committable(partitions []kafka.TopicPartition) []kafka.TopicPartition {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var offsets []kafka.TopicPartition
+	add := func(key partitionKey, p *partitionOffsets) {
+		if p.next == kafka.OffsetInvalid || p.next == p.committed {
+			return
+		}
+		topic := key.topic
+		offsets = append(offsets, kafka.TopicPartition{Topic: &topic, Partition: key.partition, Offset: p.next})
+	}
+
+	if partitions == nil {
+		for key, p := range t.partitions {
+			add(key, p)
+		}
+		return offsets
+	}
+
+	for _, tp := range partitions {
+		key := keyOf(tp)
+		if p, ok := t.partitions[key]; ok {
+			add(key, p)
+		}
+	}
+	return offsets
+}
+
+// committed는 커밋에 성공한 오프셋을 기록합니다
+func (t *offsetTracker) committed(offsets []kafka.TopicPartition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, tp := range offsets {
+		if p, ok := t.partitions[keyOf(tp)]; ok {
+			p.committed = tp.Offset
+		}
+	}
+}
+
+// remove는 회수된 파티션의 추적 정보를 삭제합니다
+func (t *offsetTracker) remove(partitions []kafka.TopicPartition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, tp := range partitions {
+		delete(t.partitions, keyOf(tp))
+	}
 }
@@ -0,0 +1,249 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/config"
+)
+
+func testPartition(topic string, partition int32, offset kafka.Offset) kafka.TopicPartition {
+	return kafka.TopicPartition{Topic: &topic, Partition: partition, Offset: offset}
+}
+
+// committedOffsets는 커밋 대상 목록을 파티션별 오프셋 맵으로 바꿉니다
+func committedOffsets(offsets []kafka.TopicPartition) map[int32]kafka.Offset {
+	result := make(map[int32]kafka.Offset)
+	for _, tp := range offsets {
+		result[tp.Partition] = tp.Offset
+	}
+	return result
+}
+
+func TestOffsetTrackerCommitsOnlyContiguousOffsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracked []kafka.Offset
+		done    []kafka.Offset
+		// want는 커밋할 오프셋(마지막 완료 오프셋 + 1)이며, 0이면 커밋할 것이 없음
+		want kafka.Offset
+	}{
+		{name: "nothing done", tracked: []kafka.Offset{10, 11, 12}, done: nil, want: 0},
+		{name: "in order", tracked: []kafka.Offset{10, 11, 12}, done: []kafka.Offset{10, 11}, want: 12},
+		{name: "later offset done first", tracked: []kafka.Offset{10, 11, 12}, done: []kafka.Offset{12}, want: 0},
+		{name: "gap blocks later offsets", tracked: []kafka.Offset{10, 11, 12}, done: []kafka.Offset{10, 12}, want: 11},
+		{name: "gap filled", tracked: []kafka.Offset{10, 11, 12}, done: []kafka.Offset{12, 11, 10}, want: 13},
+		{name: "non-contiguous broker offsets", tracked: []kafka.Offset{10, 15, 20}, done: []kafka.Offset{15, 10}, want: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newOffsetTracker()
+			for _, offset := range tt.tracked {
+				tracker.track(testPartition("chat", 0, offset))
+			}
+			for _, offset := range tt.done {
+				tracker.done(testPartition("chat", 0, offset))
+			}
+
+			offsets := tracker.committable(nil)
+			if tt.want == 0 {
+				assert.Empty(t, offsets)
+				return
+			}
+			assert.Equal(t, map[int32]kafka.Offset{0: tt.want}, committedOffsets(offsets))
+		})
+	}
+}
+
+func TestOffsetTrackerSkipsAlreadyCommitted(t *testing.T) {
+	tracker := newOffsetTracker()
+	tracker.track(testPartition("chat", 0, 10))
+	tracker.done(testPartition("chat", 0, 10))
+
+	offsets := tracker.committable(nil)
+	require.Len(t, offsets, 1)
+	tracker.committed(offsets)
+	assert.Empty(t, tracker.committable(nil))
+
+	tracker.track(testPartition("chat", 0, 11))
+	tracker.done(testPartition("chat", 0, 11))
+	assert.Equal(t, map[int32]kafka.Offset{0: 12}, committedOffsets(tracker.committable(nil)))
+}
+
+func TestRebalanceCommitsRevokedPartitions(t *testing.T) {
+	var commits [][]kafka.TopicPartition
+	consumer := &ConsumerImpl{
+		offsets: newOffsetTracker(),
+		commitOffsets: func(offsets []kafka.TopicPartition) ([]kafka.TopicPartition, error) {
+			commits = append(commits, offsets)
+			return offsets, nil
+		},
+	}
+
+	for _, partition := range []int32{0, 1} {
+		consumer.offsets.track(testPartition("chat", partition, 5))
+		consumer.offsets.track(testPartition("chat", partition, 6))
+		consumer.offsets.done(testPartition("chat", partition, 5))
+	}
+
+	// 파티션 0만 회수: 0의 완료 오프셋만 커밋하고 추적 정보를 삭제
+	require.NoError(t, consumer.onRebalance(nil, kafka.RevokedPartitions{
+		Partitions: []kafka.TopicPartition{testPartition("chat", 0, kafka.OffsetInvalid)},
+	}))
+	require.Len(t, commits, 1)
+	assert.Equal(t, map[int32]kafka.Offset{0: 6}, committedOffsets(commits[0]))
+
+	// 회수된 파티션의 늦은 완료는 무시
+	consumer.offsets.done(testPartition("chat", 0, 6))
+	assert.Equal(t, map[int32]kafka.Offset{1: 6}, committedOffsets(consumer.offsets.committable(nil)))
+
+	// 할당 이벤트는 커밋하지 않음
+	require.NoError(t, consumer.onRebalance(nil, kafka.AssignedPartitions{
+		Partitions: []kafka.TopicPartition{testPartition("chat", 0, kafka.OffsetInvalid)},
+	}))
+	assert.Len(t, commits, 1)
+}
+
+// failingConsumer는 커밋과 파티션 중지를 기록하는 브로커 없는 컨슈머를 만듭니다
+func failingConsumer(deadLetter DeadLetterHandler) (*ConsumerImpl, *[]kafka.TopicPartition) {
+	var paused []kafka.TopicPartition
+	consumer := &ConsumerImpl{
+		options: ConsumerOptions{
+			RetryPolicy: ExponentialBackoff{MaxAttempts: 3, InitialInterval: time.Millisecond},
+			DeadLetter:  deadLetter,
+		}.withDefaults(),
+		offsets: newOffsetTracker(),
+		pausePartitions: func(partitions []kafka.TopicPartition) error {
+			paused = append(paused, partitions...)
+			return nil
+		},
+	}
+	return consumer, &paused
+}
+
+func TestExhaustedRetriesWithoutDeadLetterPausePartition(t *testing.T) {
+	consumer, paused := failingConsumer(nil)
+
+	attempts := 0
+	failing := func([]byte) error {
+		attempts++
+		return errors.New("database unavailable")
+	}
+	consumer.offsets.track(testPartition("chat", 0, 10))
+	consumer.offsets.track(testPartition("chat", 0, 11))
+
+	consumer.process(context.Background(), []MessageHandler{failing}, &kafka.Message{TopicPartition: testPartition("chat", 0, 10)})
+	assert.Equal(t, 3, attempts)
+	require.Len(t, *paused, 1)
+	assert.Equal(t, int32(0), (*paused)[0].Partition)
+
+	// 실패한 오프셋은 커밋되지 않고, 뒤의 메시지가 성공해도 그 앞에서 멈춤
+	consumer.process(context.Background(), []MessageHandler{func([]byte) error { return nil }}, &kafka.Message{TopicPartition: testPartition("chat", 0, 11)})
+	assert.Empty(t, consumer.offsets.committable(nil))
+}
+
+func TestExhaustedRetriesGoToDeadLetter(t *testing.T) {
+	var dead []*kafka.Message
+	var reasons []error
+	consumer, paused := failingConsumer(func(msg *kafka.Message, err error) error {
+		dead = append(dead, msg)
+		reasons = append(reasons, err)
+		return nil
+	})
+
+	// 두 번째 핸들러만 실패해도 메시지 전체를 넘김
+	handlerErr := errors.New("bad payload")
+	consumer.offsets.track(testPartition("chat", 0, 10))
+	msg := &kafka.Message{TopicPartition: testPartition("chat", 0, 10), Value: []byte("payload")}
+	consumer.process(context.Background(), []MessageHandler{
+		func([]byte) error { return nil },
+		func([]byte) error { return handlerErr },
+	}, msg)
+
+	require.Len(t, dead, 1)
+	assert.Same(t, msg, dead[0])
+	assert.ErrorIs(t, reasons[0], handlerErr)
+	assert.Empty(t, *paused)
+	assert.Equal(t, map[int32]kafka.Offset{0: 11}, committedOffsets(consumer.offsets.committable(nil)))
+}
+
+func TestFailedDeadLetterPausesPartition(t *testing.T) {
+	consumer, paused := failingConsumer(func(*kafka.Message, error) error {
+		return errors.New("dead letter topic unavailable")
+	})
+
+	consumer.offsets.track(testPartition("chat", 2, 10))
+	consumer.process(context.Background(), []MessageHandler{func([]byte) error { return errors.New("bad payload") }},
+		&kafka.Message{TopicPartition: testPartition("chat", 2, 10)})
+
+	require.Len(t, *paused, 1)
+	assert.Equal(t, int32(2), (*paused)[0].Partition)
+	assert.Empty(t, consumer.offsets.committable(nil))
+}
+
+func TestExponentialBackoffSchedule(t *testing.T) {
+	policy := ExponentialBackoff{MaxAttempts: 8, InitialInterval: 100 * time.Millisecond, MaxInterval: time.Second}
+
+	tests := []struct {
+		attempt int
+		delay   time.Duration
+		retry   bool
+	}{
+		{attempt: 1, delay: 100 * time.Millisecond, retry: true},
+		{attempt: 2, delay: 200 * time.Millisecond, retry: true},
+		{attempt: 3, delay: 400 * time.Millisecond, retry: true},
+		{attempt: 4, delay: 800 * time.Millisecond, retry: true},
+		{attempt: 5, delay: time.Second, retry: true},
+		{attempt: 7, delay: time.Second, retry: true},
+		{attempt: 8, delay: 0, retry: false},
+	}
+
+	for _, tt := range tests {
+		delay, retry := policy.Backoff(tt.attempt, nil)
+		assert.Equal(t, tt.retry, retry, "attempt %d", tt.attempt)
+		assert.Equal(t, tt.delay, delay, "attempt %d", tt.attempt)
+	}
+}
+
+func TestNewExponentialBackoffDefaults(t *testing.T) {
+	policy := NewExponentialBackoff(0, 0)
+	assert.Equal(t, 3, policy.MaxAttempts)
+	assert.Equal(t, 100*time.Millisecond, policy.InitialInterval)
+	assert.Equal(t, 30*time.Second, policy.MaxInterval)
+
+	_, retry := NoRetry{}.Backoff(1, nil)
+	assert.False(t, retry)
+}
+
+func TestConsumeReturnsOnStopAndContextCancel(t *testing.T) {
+	// 연결할 수 없는 브로커: 메시지가 오지 않으므로 Consume은 계속 대기
+	consumer, err := NewConsumer(config.KafkaConfig{Brokers: []string{"127.0.0.1:1"}}, "chat", "test-group", ConsumerOptions{})
+	require.NoError(t, err)
+	t.Cleanup(consumer.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err = consumer.Consume(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := consumer.Consume(context.Background())
+		result <- err
+	}()
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(t, consumer.Stop(context.Background()))
+
+	select {
+	case err := <-result:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Consume was not interrupted by Stop")
+	}
+}
@@ -1,19 +1,36 @@
 package kafka
 
-import "mult-working/internal/config"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"mult-working/internal/config"
+)
+
+// ErrBrokerUnreachable은 브로커에 임계 시간 이상 연결하지 못했을 때 반환됩니다
+var ErrBrokerUnreachable = errors.New("kafka: broker unreachable")
 
 type KafkaInterface interface {
 	Produce(topic string, value []byte) error
-	Consume() ([]byte, error)
+	Consume(ctx context.Context) ([]byte, error)
 	Close() error
 	GetProducer() Producer
 	GetConsumer() Consumer
+	// Stats는 프로듀서/컨슈머 상태의 스냅샷을 반환합니다
+	Stats() Stats
+	// CheckHealth는 브로커가 임계 시간 이상 연결 불가 상태이면 에러를 반환합니다
+	CheckHealth() error
 }
 
 // Producer는 Kafka 메시지 생산자 인터페이스입니다
 type Producer interface {
 	// Produce는 지정된 토픽에 메시지를 발행합니다
 	Produce(topic string, value []byte) error
+	// ProduceWithKey는 키와 함께 메시지를 발행합니다. 같은 키의 메시지는 같은 파티션에 순서대로 기록됩니다
+	ProduceWithKey(topic string, key, value []byte) error
 	// Close는 프로듀서를 닫습니다
 	Close()
 }
@@ -26,11 +43,11 @@ type Consumer interface {
 	// AddHandler는 메시지 처리 핸들러를 추가합니다
 	AddHandler(handler MessageHandler)
 	// Start는 백그라운드에서 메시지 소비를 시작합니다
-	Start()
-	// Stop은 메시지 소비를 중지합니다
-	Stop()
-	// Consume은 메시지를 동기적으로 소비합니다
-	Consume() ([]byte, error)
+	Start(ctx context.Context) error
+	// Stop은 메시지 소비를 중지하고 처리 중인 메시지가 끝날 때까지 기다립니다
+	Stop(ctx context.Context) error
+	// Consume은 메시지를 동기적으로 소비합니다. ctx가 만료되거나 Stop이 호출되면 중단합니다
+	Consume(ctx context.Context) ([]byte, error)
 	// Close는 컨슈머를 닫습니다
 	Close()
 }
@@ -38,33 +55,129 @@ type Consumer interface {
 type Client struct {
 	Producer
 	Consumer
+
+	producer             *ProducerImpl
+	resilient            *ResilientProducer
+	consumer             *ConsumerImpl
+	metrics              *Metrics
+	unreachableThreshold time.Duration
+	stopHealthCheck      chan struct{}
 }
 
-func NewClient(cfg *config.Config) KafkaInterface {
-	producers, err := NewProducer(cfg.Kafka.Brokers)
+// NewClient는 설정을 검증하고 프로듀서와 컨슈머를 생성합니다
+func NewClient(cfg *config.Config) (KafkaInterface, error) {
+	groupID := cfg.Kafka.GroupID
+	if groupID == "" {
+		groupID = "chat-group"
+	}
+
+	metrics := NewMetrics()
+
+	producers, err := NewProducer(cfg.Kafka, metrics)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	options := ConsumerOptions{
+		Concurrency:    cfg.Kafka.Concurrency,
+		RetryPolicy:    NewExponentialBackoff(cfg.Kafka.MaxRetries, cfg.Kafka.RetryBackoff),
+		CommitInterval: cfg.Kafka.CommitInterval,
+		Metrics:        metrics,
+	}
+	if cfg.Kafka.DeadLetterTopic != "" {
+		options.DeadLetter = producers.DeadLetter(cfg.Kafka.DeadLetterTopic)
 	}
 
-	consumers, err := NewConsumer(cfg.Kafka.Topic, "chat-group", cfg.Kafka.Brokers)
+	consumers, err := NewConsumer(cfg.Kafka, cfg.Kafka.Topic, groupID, options)
 	if err != nil {
-		panic(err)
+		producers.Close()
+		return nil, err
 	}
 
+	threshold := cfg.Kafka.UnreachableThreshold
+	if threshold <= 0 {
+		threshold = 30 * time.Second
+	}
+	interval := cfg.Kafka.HealthCheckInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	// 브로커 장애 시 로컬 전달을 유지하도록 회로 차단기로 감쌈
+	resilient := NewResilientProducer(producers, metrics, BreakerOptions{
+		FailureThreshold: cfg.Kafka.CircuitBreaker.FailureThreshold,
+		RetryInterval:    cfg.Kafka.CircuitBreaker.RetryInterval,
+		BufferSize:       cfg.Kafka.CircuitBreaker.BufferSize,
+	})
+
 	client := Client{
-		Producer: producers,
-		Consumer: consumers,
+		Producer:             resilient,
+		Consumer:             consumers,
+		producer:             producers,
+		resilient:            resilient,
+		consumer:             consumers,
+		metrics:              metrics,
+		unreachableThreshold: threshold,
+		stopHealthCheck:      make(chan struct{}),
 	}
 
-	return client
+	// 백그라운드에서 주기적으로 브로커 연결 확인
+	go client.runHealthCheck(interval)
+
+	return client, nil
 }
 
 func (c Client) Close() error {
+	close(c.stopHealthCheck)
 	c.Producer.Close()
 	c.Consumer.Close()
 	return nil
 }
 
+// Stats는 프로듀서 큐 길이, 전송 결과, 컨슈머 랙, 처리 지표를 모아 반환합니다
+func (c Client) Stats() Stats {
+	var s Stats
+	c.metrics.snapshot(&s)
+	s.ProducerQueueDepth = c.producer.QueueDepth()
+	s.CircuitState, s.BufferedMessages = c.resilient.State()
+	s.DroppedMessages = c.resilient.Dropped()
+	s.ConsumerLag = c.consumer.Lag()
+	return s
+}
+
+// CheckHealth는 브로커 연결 불가 상태가 임계 시간을 넘었는지 확인합니다
+func (c Client) CheckHealth() error {
+	var s Stats
+	c.metrics.snapshot(&s)
+	if s.BrokerReachable {
+		return nil
+	}
+
+	// 아직 브로커를 확인하지 않았으면 UnreachableSince는 클라이언트 생성 시각
+	if down := time.Since(s.UnreachableSince); down > c.unreachableThreshold {
+		return fmt.Errorf("%w for %s: %s", ErrBrokerUnreachable, down.Round(time.Second), s.LastBrokerError)
+	}
+	return nil
+}
+
+// runHealthCheck는 Close가 호출될 때까지 주기적으로 브로커에 Ping을 보냅니다
+func (c Client) runHealthCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.producer.Ping(interval / 2); err != nil {
+			log.Printf("Kafka broker health check failed: %v", err)
+		}
+
+		select {
+		case <-c.stopHealthCheck:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (c Client) GetProducer() Producer {
 	return c.Producer
 }
@@ -0,0 +1,266 @@
+package kafka
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// latencyBuckets는 메시지 처리 시간 히스토그램의 상한값(초)입니다
+var latencyBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+
+// Metrics는 프로듀서와 컨슈머가 공유하는 카운터입니다. nil이어도 안전하게 호출할 수 있습니다
+type Metrics struct {
+	deliverySuccess atomic.Uint64
+	deliveryFailure atomic.Uint64
+	// consecutiveFailures는 마지막 성공 이후 연속된 전송 실패 수입니다 (회로 차단기 판단용)
+	consecutiveFailures atomic.Uint64
+	processed           atomic.Uint64
+	handlerErrors       atomic.Uint64
+
+	latencyMu     sync.Mutex
+	latencyCounts []uint64
+	latencySum    float64
+	latencyCount  uint64
+
+	brokerMu sync.Mutex
+	// contacted는 브로커 연결 결과를 한 번이라도 받았는지 나타냅니다. 그 전에는 상태를 알 수 없으므로 장애로 보지 않습니다
+	contacted        bool
+	createdAt        time.Time
+	unreachableSince time.Time
+	lastBrokerError  string
+}
+
+// NewMetrics는 새 Metrics를 생성합니다. 브로커 연결이 확인되기 전까지는 알 수 없음 상태로 시작합니다
+func NewMetrics() *Metrics {
+	return &Metrics{
+		latencyCounts:   make([]uint64, len(latencyBuckets)),
+		createdAt:       time.Now(),
+		lastBrokerError: "broker not contacted yet",
+	}
+}
+
+func (m *Metrics) deliverySucceeded() {
+	if m == nil {
+		return
+	}
+	m.deliverySuccess.Add(1)
+	m.consecutiveFailures.Store(0)
+	m.brokerReachable()
+}
+
+func (m *Metrics) deliveryFailed() {
+	if m == nil {
+		return
+	}
+	m.deliveryFailure.Add(1)
+	m.consecutiveFailures.Add(1)
+}
+
+// deliveryFailureStreak은 마지막 성공 이후 연속된 전송 실패 수를 반환합니다
+func (m *Metrics) deliveryFailureStreak() int {
+	if m == nil {
+		return 0
+	}
+	return int(m.consecutiveFailures.Load())
+}
+
+// resetDeliveryFailures는 연속 전송 실패 수를 초기화합니다 (회로를 닫을 때)
+func (m *Metrics) resetDeliveryFailures() {
+	if m == nil {
+		return
+	}
+	m.consecutiveFailures.Store(0)
+}
+
+func (m *Metrics) handlerFailed() {
+	if m == nil {
+		return
+	}
+	m.handlerErrors.Add(1)
+}
+
+// observeProcessing은 메시지 하나의 처리 시간을 기록합니다
+func (m *Metrics) observeProcessing(d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.processed.Add(1)
+
+	seconds := d.Seconds()
+	m.latencyMu.Lock()
+	defer m.latencyMu.Unlock()
+	for i, le := range latencyBuckets {
+		if seconds <= le {
+			m.latencyCounts[i]++
+		}
+	}
+	m.latencySum += seconds
+	m.latencyCount++
+}
+
+// brokerReachable은 브로커와 통신에 성공했음을 기록합니다
+func (m *Metrics) brokerReachable() {
+	if m == nil {
+		return
+	}
+	m.brokerMu.Lock()
+	defer m.brokerMu.Unlock()
+	m.contacted = true
+	m.unreachableSince = time.Time{}
+	m.lastBrokerError = ""
+}
+
+// brokerUnreachable은 브로커 연결 실패를 기록합니다. 이미 실패 상태면 최초 실패 시각을 유지합니다
+func (m *Metrics) brokerUnreachable(err error) {
+	if m == nil {
+		return
+	}
+	m.brokerMu.Lock()
+	defer m.brokerMu.Unlock()
+	m.contacted = true
+	if m.unreachableSince.IsZero() {
+		m.unreachableSince = time.Now()
+	}
+	m.lastBrokerError = err.Error()
+}
+
+// brokerDown은 브로커가 현재 연결 불가 상태인지 반환합니다. 아직 연결을 확인하지 않았으면 false입니다
+func (m *Metrics) brokerDown() bool {
+	if m == nil {
+		return false
+	}
+	m.brokerMu.Lock()
+	defer m.brokerMu.Unlock()
+	return !m.unreachableSince.IsZero()
+}
+
+// PartitionLag는 파티션별 컨슈머 랙입니다
+type PartitionLag struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Lag       int64  `json:"lag"`
+}
+
+// LatencyStats는 처리 시간 히스토그램입니다. Buckets[i]는 latencyBuckets[i]초 이하인 누적 개수입니다
+type LatencyStats struct {
+	Buckets []uint64 `json:"buckets"`
+	Sum     float64  `json:"sumSeconds"`
+	Count   uint64   `json:"count"`
+}
+
+// Stats는 Kafka 경로 상태의 스냅샷입니다
+type Stats struct {
+	ProducerQueueDepth int            `json:"producerQueueDepth"`
+	DeliverySuccess    uint64         `json:"deliverySuccess"`
+	DeliveryFailure    uint64         `json:"deliveryFailure"`
+	ConsumerLag        []PartitionLag `json:"consumerLag"`
+	MessagesProcessed  uint64         `json:"messagesProcessed"`
+	HandlerErrors      uint64         `json:"handlerErrors"`
+	ProcessingLatency  LatencyStats   `json:"processingLatency"`
+	BrokerReachable    bool           `json:"brokerReachable"`
+	UnreachableSince   time.Time      `json:"unreachableSince,omitempty"`
+	LastBrokerError    string         `json:"lastBrokerError,omitempty"`
+	CircuitState       string         `json:"circuitState"`
+	BufferedMessages   int            `json:"bufferedMessages"`
+	DroppedMessages    uint64         `json:"droppedMessages"`
+}
+
+// Degraded는 브로커 발행이 중단되어 로컬 전달만 하고 있는지 반환합니다
+func (s Stats) Degraded() bool {
+	return s.CircuitState != "" && s.CircuitState != CircuitClosed
+}
+
+// snapshot은 카운터 값을 Stats에 채웁니다
+func (m *Metrics) snapshot(s *Stats) {
+	if m == nil {
+		return
+	}
+	s.DeliverySuccess = m.deliverySuccess.Load()
+	s.DeliveryFailure = m.deliveryFailure.Load()
+	s.MessagesProcessed = m.processed.Load()
+	s.HandlerErrors = m.handlerErrors.Load()
+
+	m.latencyMu.Lock()
+	s.ProcessingLatency = LatencyStats{
+		Buckets: append([]uint64(nil), m.latencyCounts...),
+		Sum:     m.latencySum,
+		Count:   m.latencyCount,
+	}
+	m.latencyMu.Unlock()
+
+	m.brokerMu.Lock()
+	s.BrokerReachable = m.contacted && m.unreachableSince.IsZero()
+	s.UnreachableSince = m.unreachableSince
+	if !m.contacted {
+		// 아직 확인 전이면 생성 시각부터 연결되지 않은 것으로 봄 (준비 상태 판단용)
+		s.UnreachableSince = m.createdAt
+	}
+	s.LastBrokerError = m.lastBrokerError
+	m.brokerMu.Unlock()
+}
+
+// WritePrometheus는 Stats를 Prometheus 텍스트 형식으로 출력합니다
+func (s Stats) WritePrometheus(w io.Writer) {
+	fmt.Fprintln(w, "# HELP kafka_producer_queue_depth Messages waiting in the producer queue.")
+	fmt.Fprintln(w, "# TYPE kafka_producer_queue_depth gauge")
+	fmt.Fprintf(w, "kafka_producer_queue_depth %d\n", s.ProducerQueueDepth)
+
+	fmt.Fprintln(w, "# HELP kafka_producer_deliveries_total Delivery reports by result.")
+	fmt.Fprintln(w, "# TYPE kafka_producer_deliveries_total counter")
+	fmt.Fprintf(w, "kafka_producer_deliveries_total{result=\"success\"} %d\n", s.DeliverySuccess)
+	fmt.Fprintf(w, "kafka_producer_deliveries_total{result=\"failure\"} %d\n", s.DeliveryFailure)
+
+	circuitOpen := 0
+	if s.Degraded() {
+		circuitOpen = 1
+	}
+	fmt.Fprintln(w, "# HELP kafka_producer_circuit_open Whether broker publishing is suspended (local-only delivery).")
+	fmt.Fprintln(w, "# TYPE kafka_producer_circuit_open gauge")
+	fmt.Fprintf(w, "kafka_producer_circuit_open %d\n", circuitOpen)
+
+	fmt.Fprintln(w, "# HELP kafka_producer_buffered_messages Messages held while the circuit is open.")
+	fmt.Fprintln(w, "# TYPE kafka_producer_buffered_messages gauge")
+	fmt.Fprintf(w, "kafka_producer_buffered_messages %d\n", s.BufferedMessages)
+
+	fmt.Fprintln(w, "# HELP kafka_producer_dropped_messages_total Messages dropped because the buffer was full.")
+	fmt.Fprintln(w, "# TYPE kafka_producer_dropped_messages_total counter")
+	fmt.Fprintf(w, "kafka_producer_dropped_messages_total %d\n", s.DroppedMessages)
+
+	fmt.Fprintln(w, "# HELP kafka_consumer_lag Messages between the consumer position and the high watermark.")
+	fmt.Fprintln(w, "# TYPE kafka_consumer_lag gauge")
+	for _, l := range s.ConsumerLag {
+		fmt.Fprintf(w, "kafka_consumer_lag{topic=%q,partition=\"%d\"} %d\n", l.Topic, l.Partition, l.Lag)
+	}
+
+	fmt.Fprintln(w, "# HELP kafka_consumer_messages_processed_total Messages fully processed by handlers.")
+	fmt.Fprintln(w, "# TYPE kafka_consumer_messages_processed_total counter")
+	fmt.Fprintf(w, "kafka_consumer_messages_processed_total %d\n", s.MessagesProcessed)
+
+	fmt.Fprintln(w, "# HELP kafka_consumer_handler_errors_total Failed handler invocations, including retried ones.")
+	fmt.Fprintln(w, "# TYPE kafka_consumer_handler_errors_total counter")
+	fmt.Fprintf(w, "kafka_consumer_handler_errors_total %d\n", s.HandlerErrors)
+
+	fmt.Fprintln(w, "# HELP kafka_consumer_processing_seconds Time spent handling a message, including retries.")
+	fmt.Fprintln(w, "# TYPE kafka_consumer_processing_seconds histogram")
+	for i, le := range latencyBuckets {
+		var count uint64
+		if i < len(s.ProcessingLatency.Buckets) {
+			count = s.ProcessingLatency.Buckets[i]
+		}
+		fmt.Fprintf(w, "kafka_consumer_processing_seconds_bucket{le=\"%g\"} %d\n", le, count)
+	}
+	fmt.Fprintf(w, "kafka_consumer_processing_seconds_bucket{le=\"+Inf\"} %d\n", s.ProcessingLatency.Count)
+	fmt.Fprintf(w, "kafka_consumer_processing_seconds_sum %g\n", s.ProcessingLatency.Sum)
+	fmt.Fprintf(w, "kafka_consumer_processing_seconds_count %d\n", s.ProcessingLatency.Count)
+
+	reachable := 0
+	if s.BrokerReachable {
+		reachable = 1
+	}
+	fmt.Fprintln(w, "# HELP kafka_broker_reachable Whether the last broker health check succeeded.")
+	fmt.Fprintln(w, "# TYPE kafka_broker_reachable gauge")
+	fmt.Fprintf(w, "kafka_broker_reachable %d\n", reachable)
+}
@@ -0,0 +1,205 @@
+package kafka
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsSnapshotCounters(t *testing.T) {
+	m := NewMetrics()
+	m.deliverySucceeded()
+	m.deliverySucceeded()
+	m.deliveryFailed()
+	m.handlerFailed()
+	m.observeProcessing(2 * time.Millisecond)
+	m.observeProcessing(200 * time.Millisecond)
+	m.observeProcessing(10 * time.Second)
+
+	var s Stats
+	m.snapshot(&s)
+	assert.Equal(t, uint64(2), s.DeliverySuccess)
+	assert.Equal(t, uint64(1), s.DeliveryFailure)
+	assert.Equal(t, 1, m.deliveryFailureStreak())
+	assert.Equal(t, uint64(3), s.MessagesProcessed)
+	assert.Equal(t, uint64(1), s.HandlerErrors)
+
+	// 버킷은 누적 개수이고 가장 큰 상한을 넘는 값은 Count에만 반영됨
+	assert.Equal(t, []uint64{0, 1, 1, 1, 1, 2, 2, 2}, s.ProcessingLatency.Buckets)
+	assert.Equal(t, uint64(3), s.ProcessingLatency.Count)
+	assert.InDelta(t, 10.202, s.ProcessingLatency.Sum, 1e-9)
+
+	// 전송에 성공하면 연속 실패 수가 초기화되고 브로커는 연결된 것으로 봄
+	m.deliverySucceeded()
+	m.snapshot(&s)
+	assert.Equal(t, 0, m.deliveryFailureStreak())
+	assert.True(t, s.BrokerReachable)
+	assert.Empty(t, s.LastBrokerError)
+}
+
+func TestMetricsNilIsSafe(t *testing.T) {
+	var m *Metrics
+	m.deliverySucceeded()
+	m.deliveryFailed()
+	m.handlerFailed()
+	m.observeProcessing(time.Millisecond)
+	m.brokerUnreachable(errors.New("down"))
+	assert.False(t, m.brokerDown())
+
+	var s Stats
+	m.snapshot(&s)
+	assert.Equal(t, Stats{}, s)
+}
+
+func TestMetricsBrokerState(t *testing.T) {
+	m := NewMetrics()
+	var s Stats
+	m.snapshot(&s)
+	assert.False(t, s.BrokerReachable)
+	assert.False(t, m.brokerDown(), "연결 확인 전에는 장애로 보지 않음")
+	assert.Equal(t, m.createdAt, s.UnreachableSince)
+
+	m.brokerUnreachable(errors.New("connection refused"))
+	m.snapshot(&s)
+	since := s.UnreachableSince
+	assert.True(t, m.brokerDown())
+	assert.False(t, s.BrokerReachable)
+	assert.Equal(t, "connection refused", s.LastBrokerError)
+
+	// 이어지는 실패는 최초 실패 시각을 유지하고 마지막 에러만 바꿈
+	m.brokerUnreachable(errors.New("timed out"))
+	m.snapshot(&s)
+	assert.Equal(t, since, s.UnreachableSince)
+	assert.Equal(t, "timed out", s.LastBrokerError)
+
+	m.brokerReachable()
+	m.snapshot(&s)
+	assert.True(t, s.BrokerReachable)
+	assert.False(t, m.brokerDown())
+	assert.True(t, s.UnreachableSince.IsZero())
+}
+
+func TestWritePrometheusFormat(t *testing.T) {
+	s := Stats{
+		ProducerQueueDepth: 4,
+		DeliverySuccess:    10,
+		DeliveryFailure:    2,
+		ConsumerLag:        []PartitionLag{{Topic: "chat", Partition: 0, Lag: 7}, {Topic: "chat", Partition: 1, Lag: 0}},
+		MessagesProcessed:  9,
+		HandlerErrors:      3,
+		ProcessingLatency:  LatencyStats{Buckets: []uint64{1, 2, 3, 4, 5, 6, 7, 8}, Sum: 1.5, Count: 9},
+		BrokerReachable:    true,
+		CircuitState:       CircuitOpen,
+		BufferedMessages:   5,
+		DroppedMessages:    1,
+	}
+	var buf bytes.Buffer
+	s.WritePrometheus(&buf)
+	out := buf.String()
+
+	for _, line := range []string{
+		"kafka_producer_queue_depth 4",
+		`kafka_producer_deliveries_total{result="success"} 10`,
+		`kafka_producer_deliveries_total{result="failure"} 2`,
+		"kafka_producer_circuit_open 1",
+		"kafka_producer_buffered_messages 5",
+		"kafka_producer_dropped_messages_total 1",
+		`kafka_consumer_lag{topic="chat",partition="0"} 7`,
+		`kafka_consumer_lag{topic="chat",partition="1"} 0`,
+		"kafka_consumer_messages_processed_total 9",
+		"kafka_consumer_handler_errors_total 3",
+		`kafka_consumer_processing_seconds_bucket{le="0.001"} 1`,
+		`kafka_consumer_processing_seconds_bucket{le="5"} 8`,
+		`kafka_consumer_processing_seconds_bucket{le="+Inf"} 9`,
+		"kafka_consumer_processing_seconds_sum 1.5",
+		"kafka_consumer_processing_seconds_count 9",
+		"kafka_broker_reachable 1",
+	} {
+		assert.Contains(t, out, line+"\n")
+	}
+
+	// 모든 지표는 HELP와 TYPE 주석 뒤에 나오고 값 줄은 "이름{레이블} 값" 형식
+	declared := map[string]string{}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "# HELP ") {
+			name := strings.Fields(line)[2]
+			require.Less(t, i+1, len(lines))
+			typeLine := strings.Fields(lines[i+1])
+			require.Len(t, typeLine, 4, lines[i+1])
+			assert.Equal(t, []string{"#", "TYPE", name}, typeLine[:3])
+			declared[name] = typeLine[3]
+			continue
+		}
+		if strings.HasPrefix(line, "# TYPE ") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		require.Len(t, fields, 2, line)
+		name := fields[0]
+		if i := strings.IndexByte(name, '{'); i >= 0 {
+			assert.True(t, strings.HasSuffix(name, "}"), line)
+			name = name[:i]
+		}
+		if _, ok := declared[name]; !ok {
+			for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+				if base := strings.TrimSuffix(name, suffix); base != name && declared[base] == "histogram" {
+					name = base
+				}
+			}
+		}
+		assert.Contains(t, declared, name, line)
+	}
+	assert.Equal(t, "counter", declared["kafka_producer_deliveries_total"])
+	assert.Equal(t, "gauge", declared["kafka_consumer_lag"])
+	assert.Equal(t, "histogram", declared["kafka_consumer_processing_seconds"])
+}
+
+func TestWritePrometheusClosedCircuitAndUnreachableBroker(t *testing.T) {
+	var buf bytes.Buffer
+	Stats{CircuitState: CircuitClosed}.WritePrometheus(&buf)
+	assert.Contains(t, buf.String(), "kafka_producer_circuit_open 0\n")
+	assert.Contains(t, buf.String(), "kafka_broker_reachable 0\n")
+	// 빈 히스토그램도 모든 버킷을 0으로 출력
+	assert.Contains(t, buf.String(), `kafka_consumer_processing_seconds_bucket{le="0.5"} 0`+"\n")
+}
+
+func TestCheckHealthTransitions(t *testing.T) {
+	m := NewMetrics()
+	client := Client{metrics: m, unreachableThreshold: 50 * time.Millisecond}
+
+	// 연결 확인 전이라도 임계 시간 안이면 준비 상태
+	assert.NoError(t, client.CheckHealth())
+
+	m.brokerReachable()
+	assert.NoError(t, client.CheckHealth())
+
+	// 연결이 끊긴 직후에는 아직 준비 상태를 유지
+	m.brokerUnreachable(errors.New("connection refused"))
+	assert.NoError(t, client.CheckHealth())
+
+	time.Sleep(60 * time.Millisecond)
+	err := client.CheckHealth()
+	assert.ErrorIs(t, err, ErrBrokerUnreachable)
+	assert.Contains(t, err.Error(), "connection refused")
+
+	// 다시 연결되면 바로 준비 상태로 돌아옴
+	m.brokerReachable()
+	assert.NoError(t, client.CheckHealth())
+}
+
+func TestCheckHealthFailsWhenBrokerNeverContacted(t *testing.T) {
+	m := NewMetrics()
+	client := Client{metrics: m, unreachableThreshold: 20 * time.Millisecond}
+
+	time.Sleep(30 * time.Millisecond)
+	err := client.CheckHealth()
+	assert.ErrorIs(t, err, ErrBrokerUnreachable)
+	assert.Contains(t, err.Error(), "broker not contacted yet")
+}
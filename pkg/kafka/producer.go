@@ -1,20 +1,26 @@
 package kafka
 
 import (
+	"time"
+
 	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"mult-working/internal/config"
 )
 
 // Producer는 Kafka 메시지 프로듀서를 래핑합니다
 type ProducerImpl struct {
 	producer *kafka.Producer
+	metrics  *Metrics
 }
 
 // NewProducer는 새 Kafka 프로듀서를 생성합니다
-func NewProducer(bootstrapServers []string) (*ProducerImpl, error) {
-	p, err := kafka.NewProducer(&kafka.ConfigMap{
-		"bootstrap.servers": bootstrapServers[0],
-	})
+func NewProducer(cfg config.KafkaConfig, metrics *Metrics) (*ProducerImpl, error) {
+	cm, err := newConfigMap(cfg)
+	if err != nil {
+		return nil, err
+	}
 
+	p, err := kafka.NewProducer(&cm)
 	if err != nil {
 		return nil, err
 	}
@@ -26,22 +32,86 @@ func NewProducer(bootstrapServers []string) (*ProducerImpl, error) {
 			case *kafka.Message:
 				if ev.TopicPartition.Error != nil {
 					// 메시지 전송 실패
+					metrics.deliveryFailed()
+				} else {
+					metrics.deliverySucceeded()
+				}
+			case kafka.Error:
+				if ev.Code() == kafka.ErrAllBrokersDown {
+					metrics.brokerUnreachable(ev)
 				}
 			}
 		}
 	}()
 
-	return &ProducerImpl{producer: p}, nil
+	return &ProducerImpl{producer: p, metrics: metrics}, nil
 }
 
 // Produce는 지정된 토픽에 메시지를 발행합니다
 func (p *ProducerImpl) Produce(topic string, value []byte) error {
+	return p.ProduceWithKey(topic, nil, value)
+}
+
+// ProduceWithKey는 키와 함께 지정된 토픽에 메시지를 발행합니다
+func (p *ProducerImpl) ProduceWithKey(topic string, key, value []byte) error {
 	return p.producer.Produce(&kafka.Message{
 		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            key,
 		Value:          value,
 	}, nil)
 }
 
+// DeadLetter는 실패한 메시지를 원래 위치와 에러를 헤더에 담아 topic에 발행하고, 브로커가 받을 때까지 기다립니다.
+// ConsumerOptions.DeadLetter로 사용합니다
+func (p *ProducerImpl) DeadLetter(topic string) DeadLetterHandler {
+	return func(msg *kafka.Message, failure error) error {
+		headers := append([]kafka.Header(nil), msg.Headers...)
+		headers = append(headers,
+			kafka.Header{Key: "dlq.source", Value: []byte(msg.TopicPartition.String())},
+			kafka.Header{Key: "dlq.error", Value: []byte(failure.Error())},
+		)
+
+		delivery := make(chan kafka.Event, 1)
+		err := p.producer.Produce(&kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+			Key:            msg.Key,
+			Value:          msg.Value,
+			Headers:        headers,
+		}, delivery)
+		if err != nil {
+			return err
+		}
+
+		report := (<-delivery).(*kafka.Message)
+		if report.TopicPartition.Error != nil {
+			p.metrics.deliveryFailed()
+			return report.TopicPartition.Error
+		}
+		p.metrics.deliverySucceeded()
+		return nil
+	}
+}
+
+// QueueDepth는 아직 브로커로 전송되지 않았거나 전송 결과를 기다리는 메시지 수를 반환합니다
+func (p *ProducerImpl) QueueDepth() int {
+	return p.producer.Len()
+}
+
+// Ping은 메타데이터 요청으로 브로커 연결을 확인하고 결과를 Metrics에 기록합니다
+func (p *ProducerImpl) Ping(timeout time.Duration) error {
+	if _, err := p.producer.GetMetadata(nil, false, int(timeout.Milliseconds())); err != nil {
+		p.metrics.brokerUnreachable(err)
+		return err
+	}
+	p.metrics.brokerReachable()
+	return nil
+}
+
+// Flush는 대기 중인 메시지가 전송될 때까지 최대 timeout만큼 기다리고, 남은 메시지 수를 반환합니다
+func (p *ProducerImpl) Flush(timeout time.Duration) int {
+	return p.producer.Flush(int(timeout.Milliseconds()))
+}
+
 // Close는 프로듀서를 닫습니다
 func (p *ProducerImpl) Close() {
 	p.producer.Close()
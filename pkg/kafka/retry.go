@@ -0,0 +1,60 @@
+package kafka
+
+import (
+	"time"
+)
+
+// RetryPolicy는 핸들러 실패 시 재시도 여부와 대기 시간을 결정합니다.
+// 재시도를 멈춘 메시지는 ConsumerOptions.DeadLetter로 넘어가며, DeadLetter가 없거나 실패하면
+// 오프셋을 커밋하지 않고 해당 파티션 소비를 멈춥니다 (실패한 메시지를 버리지 않음)
+type RetryPolicy interface {
+	// Backoff는 attempt번째(1부터 시작) 실패 후 다음 시도까지의 대기 시간을 반환합니다.
+	// 더 이상 재시도하지 않으려면 false를 반환합니다.
+	Backoff(attempt int, err error) (time.Duration, bool)
+}
+
+// NoRetry는 재시도하지 않는 정책입니다
+type NoRetry struct{}
+
+// Backoff는 항상 재시도하지 않음을 반환합니다
+func (NoRetry) Backoff(int, error) (time.Duration, bool) {
+	return 0, false
+}
+
+// ExponentialBackoff는 지수적으로 증가하는 대기 시간으로 재시도하는 정책입니다
+type ExponentialBackoff struct {
+	MaxAttempts     int
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+// NewExponentialBackoff는 기본값이 채워진 ExponentialBackoff를 생성합니다
+func NewExponentialBackoff(maxAttempts int, initialInterval time.Duration) ExponentialBackoff {
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	if initialInterval <= 0 {
+		initialInterval = 100 * time.Millisecond
+	}
+	return ExponentialBackoff{
+		MaxAttempts:     maxAttempts,
+		InitialInterval: initialInterval,
+		MaxInterval:     30 * time.Second,
+	}
+}
+
+// Backoff는 attempt에 따라 InitialInterval * 2^(attempt-1)만큼 대기하도록 합니다
+func (b ExponentialBackoff) Backoff(attempt int, _ error) (time.Duration, bool) {
+	if attempt >= b.MaxAttempts {
+		return 0, false
+	}
+
+	delay := b.InitialInterval
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if b.MaxInterval > 0 && delay >= b.MaxInterval {
+			return b.MaxInterval, true
+		}
+	}
+	return delay, true
+}
@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LogMailer는 메일을 발송하지 않고 로그로 출력합니다 (개발용)
+type LogMailer struct {
+	from string
+}
+
+// NewLogMailer는 새 LogMailer를 생성합니다
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+// Send는 메일 내용을 로그로 출력합니다
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("[mail] from=%s to=%s subject=%q\n%s", m.from, msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// FileMailer는 메일을 디렉터리에 .eml 파일로 저장합니다 (개발, 테스트용)
+type FileMailer struct {
+	from string
+	dir  string
+}
+
+// NewFileMailer는 새 FileMailer를 생성하고 디렉터리가 없으면 만듭니다
+func NewFileMailer(from, dir string) (*FileMailer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("mailer.directory: required when driver is %q", DriverFile)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileMailer{from: from, dir: dir}, nil
+}
+
+// Send는 메일을 "<시각>-<수신자>.eml" 파일로 저장합니다
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	data, err := render(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405.000000000"), recipient)
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o600)
+}
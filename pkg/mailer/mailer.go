@@ -0,0 +1,76 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"time"
+
+	"mult-working/internal/config"
+)
+
+// Message는 발송할 텍스트 메일입니다
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer는 메일 발송 인터페이스입니다
+type Mailer interface {
+	// Send는 메일 하나를 발송합니다
+	Send(ctx context.Context, msg Message) error
+}
+
+// 메일 드라이버
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+	DriverFile = "file"
+)
+
+// New는 설정된 드라이버의 Mailer를 생성합니다. 드라이버가 비어 있으면 로그로 출력합니다
+func New(cfg *config.Config) (Mailer, error) {
+	mc := cfg.Mailer
+	if mc.From == "" {
+		mc.From = "no-reply@localhost"
+	}
+	if _, err := mail.ParseAddress(mc.From); err != nil {
+		return nil, fmt.Errorf("mailer.from: %w", err)
+	}
+
+	switch mc.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(mc)
+	case "", DriverLog:
+		return NewLogMailer(mc.From), nil
+	case DriverFile:
+		return NewFileMailer(mc.From, mc.Directory)
+	default:
+		return nil, fmt.Errorf("mailer.driver: %q is not supported (use %q, %q or %q)", mc.Driver, DriverSMTP, DriverLog, DriverFile)
+	}
+}
+
+// render는 메시지를 RFC 5322 형식으로 직렬화합니다
+func render(from string, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(msg.Body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
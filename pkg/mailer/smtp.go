@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+
+	"mult-working/internal/config"
+)
+
+// SMTPMailer는 SMTP 서버로 메일을 발송합니다. 서버가 지원하면 STARTTLS를 사용합니다
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer는 새 SMTPMailer를 생성합니다
+func NewSMTPMailer(cfg config.MailerConfig) (*SMTPMailer, error) {
+	if cfg.SMTP.Host == "" {
+		return nil, fmt.Errorf("mailer.smtp.host: required when driver is %q", DriverSMTP)
+	}
+	port := cfg.SMTP.Port
+	if port == 0 {
+		port = 587
+	}
+
+	m := &SMTPMailer{
+		addr: net.JoinHostPort(cfg.SMTP.Host, strconv.Itoa(port)),
+		from: cfg.From,
+	}
+	if cfg.SMTP.Username != "" {
+		m.auth = smtp.PlainAuth("", cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.Host)
+	}
+	return m, nil
+}
+
+// Send는 메일을 발송합니다
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", msg.To, err)
+	}
+
+	data, err := render(m.from, msg)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, from.Address, []string{to.Address}, data)
+}
@@ -0,0 +1,134 @@
+// Package password는 비밀번호 해싱과 검증을 담당합니다.
+// 새 해시는 Argon2id(PHC 문자열 형식)로 만들고, 이전에 저장된 bcrypt 해시도 검증할 수 있습니다
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"mult-working/internal/config"
+)
+
+// Params는 Argon2id 파라미터입니다. Memory는 KiB 단위입니다
+type Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultParams는 OWASP 권장값을 따른 기본 파라미터입니다 (64MiB, 3회, 병렬 2)
+var DefaultParams = Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+var b64 = base64.RawStdEncoding
+
+// Hasher는 설정된 파라미터로 비밀번호를 해시하고 저장된 해시를 검증합니다
+type Hasher struct {
+	params Params
+}
+
+// NewHasher는 새 Hasher를 생성합니다. 설정되지 않은 값은 기본값을 사용합니다
+func NewHasher(cfg config.Argon2Config) *Hasher {
+	params := DefaultParams
+	if cfg.Memory > 0 {
+		params.Memory = cfg.Memory
+	}
+	if cfg.Iterations > 0 {
+		params.Iterations = cfg.Iterations
+	}
+	if cfg.Parallelism > 0 {
+		params.Parallelism = cfg.Parallelism
+	}
+	if cfg.SaltLength > 0 {
+		params.SaltLength = cfg.SaltLength
+	}
+	if cfg.KeyLength > 0 {
+		params.KeyLength = cfg.KeyLength
+	}
+	return &Hasher{params: params}
+}
+
+// Hash는 비밀번호의 Argon2id 해시를 "$argon2id$v=19$m=...,t=...,p=...$<salt>$<hash>" 형식으로 반환합니다
+func (h *Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+}
+
+// Verify는 비밀번호가 저장된 해시와 일치하는지 확인합니다.
+// 일치하지만 해시가 bcrypt이거나 현재 파라미터와 다르면 needsRehash가 true이며, 호출자가 새 해시로 바꿔 저장해야 합니다
+func (h *Hasher) Verify(password, encoded string) (ok, needsRehash bool) {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(encoded)
+		if err != nil {
+			return false, false
+		}
+		actual := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(actual, key) != 1 {
+			return false, false
+		}
+		current := h.params
+		return true, params.Memory != current.Memory || params.Iterations != current.Iterations ||
+			params.Parallelism != current.Parallelism || uint32(len(salt)) != current.SaltLength || uint32(len(key)) != current.KeyLength
+
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		if bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) != nil {
+			return false, false
+		}
+		return true, true
+
+	default:
+		// 비밀번호가 없는 계정(SSO, 봇) 등
+		return false, false
+	}
+}
+
+func decodeArgon2id(encoded string) (Params, []byte, []byte, error) {
+	var params Params
+
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, fmt.Errorf("password: malformed argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("password: unsupported argon2 version")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("password: malformed argon2id parameters: %w", err)
+	}
+
+	salt, err := b64.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("password: malformed salt: %w", err)
+	}
+	key, err := b64.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, fmt.Errorf("password: malformed hash")
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
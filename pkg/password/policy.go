@@ -0,0 +1,86 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"mult-working/internal/config"
+	"mult-working/internal/errors"
+)
+
+// Policy는 새 비밀번호가 지켜야 할 규칙입니다 (길이, 흔한 비밀번호 금지, 사용자 이름 재사용 금지)
+type Policy struct {
+	minLength int
+	maxLength int
+	blocklist map[string]struct{}
+}
+
+// NewPolicy는 설정으로 Policy를 생성합니다. 금지 목록 파일이 지정되었는데 읽을 수 없으면 에러를 반환합니다
+func NewPolicy(cfg config.PasswordConfig) (*Policy, error) {
+	p := &Policy{
+		minLength: cfg.MinLength,
+		maxLength: cfg.MaxLength,
+		blocklist: make(map[string]struct{}),
+	}
+	if p.minLength <= 0 {
+		p.minLength = 8
+	}
+	// 해시 비용을 이용한 서비스 거부를 막기 위한 상한
+	if p.maxLength <= 0 {
+		p.maxLength = 128
+	}
+
+	if cfg.BlocklistFile != "" {
+		if err := p.loadBlocklist(cfg.BlocklistFile); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// loadBlocklist는 한 줄에 하나씩 적힌 흔한 비밀번호 목록을 읽습니다. 빈 줄과 #으로 시작하는 줄은 무시합니다
+func (p *Policy) loadBlocklist(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("password: failed to open blocklist: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.blocklist[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("password: failed to read blocklist: %w", err)
+	}
+	return nil
+}
+
+// Validate는 비밀번호가 정책을 지키는지 확인합니다. 위반 시 이유를 담아 errors.ErrWeakPassword를 감싼 에러를 반환합니다
+func (p *Policy) Validate(password, username string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.minLength {
+		return fmt.Errorf("%w: must be at least %d characters", errors.ErrWeakPassword, p.minLength)
+	}
+	if length > p.maxLength {
+		return fmt.Errorf("%w: must be at most %d characters", errors.ErrWeakPassword, p.maxLength)
+	}
+
+	lower := strings.ToLower(password)
+	if _, blocked := p.blocklist[lower]; blocked {
+		return fmt.Errorf("%w: too common", errors.ErrWeakPassword)
+	}
+	// 짧은 이름은 우연히 포함될 수 있으므로 같은지만 확인
+	name := strings.ToLower(username)
+	if name != "" && (lower == name || (utf8.RuneCountInString(name) >= 4 && strings.Contains(lower, name))) {
+		return fmt.Errorf("%w: must not contain the username", errors.ErrWeakPassword)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// Package tokenverify는 다른 서비스가 채팅 서비스의 v2.public 토큰을
+// 공개 키 엔드포인트(/api/auth/keys)만으로 오프라인 검증할 수 있게 해줍니다.
+//
+// 서명과 만료만 확인하므로, 로그아웃 등으로 폐기된 토큰은 만료 전까지 유효하게 보일 수 있습니다.
+package tokenverify
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/o1egl/paseto"
+)
+
+var (
+	ErrUnknownKey   = errors.New("tokenverify: unknown key id")
+	ErrInvalidToken = errors.New("tokenverify: invalid token")
+	ErrTokenExpired = errors.New("tokenverify: token expired")
+)
+
+// Claims는 채팅 서비스가 발급하는 액세스 토큰 페이로드입니다
+type Claims struct {
+	UserID    uint   `json:"user_id"`
+	TokenID   string `json:"jti"`
+	SessionID string `json:"sid,omitempty"`
+	IssuedAt  int64  `json:"issued_at"`
+	ExpiresAt int64  `json:"expires_at"`
+	// GuestRoomID가 0이 아니면 그 채팅방에만 접근할 수 있는 게스트 토큰입니다
+	GuestRoomID uint     `json:"guest_room,omitempty"`
+	Scopes      []string `json:"scp,omitempty"`
+}
+
+type publicKey struct {
+	KeyID   string `json:"kid"`
+	Version string `json:"version"`
+	Purpose string `json:"purpose"`
+	Key     string `json:"key"`
+}
+
+type footer struct {
+	KeyID string `json:"kid"`
+}
+
+// Verifier는 공개 키를 캐시하고, 모르는 키 ID를 만나면 다시 가져옵니다
+type Verifier struct {
+	keysURL    string
+	client     *http.Client
+	minRefresh time.Duration
+	paseto     *paseto.V2
+
+	mu        sync.RWMutex
+	keys      map[string]ed25519.PublicKey
+	fetchedAt time.Time
+}
+
+// New는 keysURL(예: https://chat.example.com/api/auth/keys)에서 키를 가져오는 Verifier를 생성합니다
+func New(keysURL string) *Verifier {
+	return &Verifier{
+		keysURL:    keysURL,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		minRefresh: 30 * time.Second,
+		paseto:     paseto.NewV2(),
+		keys:       make(map[string]ed25519.PublicKey),
+	}
+}
+
+// Verify는 토큰 서명과 만료를 확인하고 클레임을 반환합니다
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	var f footer
+	if err := paseto.ParseFooter(token, &f); err != nil || f.KeyID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	key, err := v.key(f.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := v.paseto.Verify(token, key, &claims, nil); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.UserID == 0 || claims.ExpiresAt == 0 {
+		return nil, ErrInvalidToken
+	}
+	if time.Now().Unix() > claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+
+	return &claims, nil
+}
+
+// key는 캐시에서 키를 찾고, 없으면 (최소 갱신 간격을 지키며) 키 목록을 다시 가져옵니다
+func (v *Verifier) key(keyID string) (ed25519.PublicKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[keyID]
+	fetchedAt := v.fetchedAt
+	v.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	if time.Since(fetchedAt) < v.minRefresh {
+		return nil, ErrUnknownKey
+	}
+	if err := v.Refresh(); err != nil {
+		return nil, err
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if key, ok := v.keys[keyID]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// Refresh는 공개 키 목록을 다시 가져옵니다
+func (v *Verifier) Refresh() error {
+	resp, err := v.client.Get(v.keysURL)
+	if err != nil {
+		return fmt.Errorf("tokenverify: fetch keys: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tokenverify: fetch keys: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Keys []publicKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("tokenverify: decode keys: %w", err)
+	}
+
+	keys := make(map[string]ed25519.PublicKey, len(body.Keys))
+	for _, k := range body.Keys {
+		if k.Version != "v2" || k.Purpose != "public" {
+			continue
+		}
+		// 서버 설정(auth.keys)과 같은 표준 base64
+		raw, err := base64.StdEncoding.DecodeString(k.Key)
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			continue
+		}
+		keys[k.KeyID] = ed25519.PublicKey(raw)
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+	return nil
+}
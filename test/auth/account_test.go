@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/service"
+	"mult-working/test/mocks"
+)
+
+var mailLink = regexp.MustCompile(`https?://\S+`)
+
+func newAccountService(t *testing.T) (*service.AuthService, *service.AccountService, *mocks.Mailer) {
+	t.Helper()
+	db, authService := newAuthService(t)
+	mailbox := &mocks.Mailer{}
+	accounts := service.NewAccountService(db, config.AuthConfig{LinkBaseURL: "https://chat.example.com"}, authService, mailbox)
+	require.NoError(t, authService.Register(dto.RegisterRequest{Username: "alice", Email: "alice@example.com", Password: "correct horse battery"}))
+	return authService, accounts, mailbox
+}
+
+// linkToken은 메일 본문의 링크에서 토큰을 꺼냅니다
+func linkToken(t *testing.T, body string) string {
+	t.Helper()
+	link, err := url.Parse(mailLink.FindString(body))
+	require.NoError(t, err)
+	token := link.Query().Get("token")
+	require.NotEmpty(t, token)
+	return token
+}
+
+func TestVerificationTokenIsOpaqueAndSingleUse(t *testing.T) {
+	_, accounts, mailbox := newAccountService(t)
+
+	accounts.SendVerification(context.Background(), "Alice@Example.com")
+	accounts.Wait()
+	require.Len(t, mailbox.Sent(), 1)
+	token := linkToken(t, mailbox.Sent()[0].Body)
+
+	// 서명 토큰이 아니므로 public 모드에서도 사용자 정보가 드러나지 않음
+	assert.False(t, strings.HasPrefix(token, "v2."))
+	assert.NotContains(t, token, "alice")
+
+	require.NoError(t, accounts.VerifyEmail(token))
+	assert.ErrorIs(t, accounts.VerifyEmail(token), errors.ErrInvalidToken)
+}
+
+func TestPasswordResetTokenCannotBeUsedForVerification(t *testing.T) {
+	authService, accounts, mailbox := newAccountService(t)
+
+	accounts.RequestPasswordReset(context.Background(), "alice@example.com")
+	accounts.Wait()
+	require.Len(t, mailbox.Sent(), 1)
+	token := linkToken(t, mailbox.Sent()[0].Body)
+
+	assert.ErrorIs(t, accounts.VerifyEmail(token), errors.ErrInvalidToken)
+	require.NoError(t, accounts.ResetPassword(token, "a brand new passphrase"))
+
+	_, err := authService.Login(dto.LoginRequest{Username: "alice", Password: "a brand new passphrase"}, service.ClientInfo{})
+	require.NoError(t, err)
+}
+
+func TestUnknownEmailSendsNothing(t *testing.T) {
+	_, accounts, mailbox := newAccountService(t)
+
+	accounts.SendVerification(context.Background(), "nobody@example.com")
+	accounts.RequestPasswordReset(context.Background(), "nobody@example.com")
+	accounts.Wait()
+	assert.Empty(t, mailbox.Sent())
+}
+
+func TestNewerActionTokenInvalidatesOlder(t *testing.T) {
+	_, accounts, mailbox := newAccountService(t)
+
+	accounts.RequestPasswordReset(context.Background(), "alice@example.com")
+	accounts.Wait()
+	accounts.RequestPasswordReset(context.Background(), "alice@example.com")
+	accounts.Wait()
+	require.Len(t, mailbox.Sent(), 2)
+
+	first := linkToken(t, mailbox.Sent()[0].Body)
+	assert.ErrorIs(t, accounts.ResetPassword(first, "a brand new passphrase"), errors.ErrInvalidToken)
+	require.NoError(t, accounts.ResetPassword(linkToken(t, mailbox.Sent()[1].Body), "a brand new passphrase"))
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+)
+
+// createUser는 역할과 종류를 지정해 사용자를 직접 만듭니다
+func createUser(t *testing.T, db *gorm.DB, user models.User) uint {
+	t.Helper()
+	if user.Email == "" {
+		user.Email = user.Username + "@example.com"
+	}
+	require.NoError(t, db.Create(&user).Error)
+	return user.ID
+}
+
+func roleOf(t *testing.T, db *gorm.DB, userID uint) string {
+	t.Helper()
+	var user models.User
+	require.NoError(t, db.First(&user, userID).Error)
+	return user.Role
+}
+
+func TestSetRoleChangesRole(t *testing.T) {
+	db, authService := newAuthService(t)
+	admin := service.NewAdminService(db, authService)
+	aliceID := createUser(t, db, models.User{Username: "alice"})
+
+	resp, err := admin.SetRole(aliceID, models.RoleModerator)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleModerator, resp.Role)
+	assert.Equal(t, models.RoleModerator, roleOf(t, db, aliceID))
+
+	_, err = admin.SetRole(aliceID, "owner")
+	assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	_, err = admin.SetRole(aliceID+100, models.RoleUser)
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+}
+
+func TestSetRoleRejectsBotsAndGuests(t *testing.T) {
+	db, authService := newAuthService(t)
+	admin := service.NewAdminService(db, authService)
+	botID := createUser(t, db, models.User{Username: "bot", IsBot: true})
+	guestID := createUser(t, db, models.User{Username: "guest", IsGuest: true})
+
+	for _, userID := range []uint{botID, guestID} {
+		for _, role := range []string{models.RoleModerator, models.RoleAdmin} {
+			_, err := admin.SetRole(userID, role)
+			assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+		}
+		_, err := admin.SetRole(userID, models.RoleUser)
+		assert.NoError(t, err)
+		assert.Equal(t, models.RoleUser, roleOf(t, db, userID))
+	}
+}
+
+func TestSetRoleKeepsLastAdmin(t *testing.T) {
+	db, authService := newAuthService(t)
+	admin := service.NewAdminService(db, authService)
+	rootID := createUser(t, db, models.User{Username: "root", Role: models.RoleAdmin})
+
+	_, err := admin.SetRole(rootID, models.RoleModerator)
+	assert.ErrorIs(t, err, errors.ErrLastAdmin)
+	assert.Equal(t, models.RoleAdmin, roleOf(t, db, rootID))
+
+	// 관리자가 둘이면 한 명은 강등할 수 있음
+	otherID := createUser(t, db, models.User{Username: "other", Role: models.RoleAdmin})
+	_, err = admin.SetRole(rootID, models.RoleUser)
+	require.NoError(t, err)
+	_, err = admin.SetRole(otherID, models.RoleUser)
+	assert.ErrorIs(t, err, errors.ErrLastAdmin)
+}
+
+func TestBootstrapAdminCreatesAdmin(t *testing.T) {
+	db, authService := newAuthService(t)
+	admin := service.NewAdminService(db, authService)
+
+	user, created, err := admin.BootstrapAdmin("root", "root@example.com", "correct horse battery")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, models.RoleAdmin, user.Role)
+	assert.NotNil(t, user.EmailVerifiedAt)
+
+	// 만든 계정으로 로그인 가능
+	resp := loginFrom(t, authService, "root", chromeOnWindows)
+	assert.Equal(t, user.ID, verifiedUserID(t, authService, resp.Token))
+
+	// 비밀번호 정책과 이메일 중복 확인
+	_, _, err = admin.BootstrapAdmin("second", "second@example.com", "short")
+	assert.ErrorIs(t, err, errors.ErrWeakPassword)
+	_, _, err = admin.BootstrapAdmin("second", "root@example.com", "correct horse battery")
+	assert.ErrorIs(t, err, errors.ErrUserExists)
+	_, _, err = admin.BootstrapAdmin("second", "", "")
+	assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+}
+
+func TestBootstrapAdminPromotesExistingUser(t *testing.T) {
+	db, authService := newAuthService(t)
+	admin := service.NewAdminService(db, authService)
+	aliceID := createUser(t, db, models.User{Username: "alice"})
+
+	user, created, err := admin.BootstrapAdmin("alice", "", "")
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, aliceID, user.ID)
+	assert.Equal(t, models.RoleAdmin, roleOf(t, db, aliceID))
+}
+
+func TestBootstrapAdminRejectsBotsAndGuests(t *testing.T) {
+	db, authService := newAuthService(t)
+	admin := service.NewAdminService(db, authService)
+	botID := createUser(t, db, models.User{Username: "bot", IsBot: true})
+	guestID := createUser(t, db, models.User{Username: "guest", IsGuest: true})
+
+	for name, userID := range map[string]uint{"bot": botID, "guest": guestID} {
+		_, _, err := admin.BootstrapAdmin(name, "", "")
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest, name)
+		assert.Equal(t, models.RoleUser, roleOf(t, db, userID), name)
+	}
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+)
+
+// newBotKey는 alice가 관리하는 봇과 지정된 권한 범위의 API 키를 만듭니다
+func newBotKey(t *testing.T, authService *service.AuthService, scopes ...string) (ownerID, botID uint, key *dto.CreatedAPIKeyResponse) {
+	t.Helper()
+	ownerID = verifiedUserID(t, authService, loginFrom(t, authService, "alice", chromeOnWindows).Token)
+
+	bot, err := authService.APIKeys().CreateBot(ownerID, dto.CreateBotRequest{Username: "deploy-bot"})
+	require.NoError(t, err)
+	key, err = authService.APIKeys().CreateKey(ownerID, bot.ID, dto.CreateAPIKeyRequest{Name: "ci", Scopes: scopes})
+	require.NoError(t, err)
+	return ownerID, bot.ID, key
+}
+
+func TestAPIKeyAuthenticates(t *testing.T) {
+	db, authService := newAuthService(t)
+	_, botID, created := newBotKey(t, authService, models.ScopeRoomsRead, models.ScopeMessagesWrite, models.ScopeRoomsRead)
+	assert.True(t, strings.HasPrefix(created.Key, "mwk_"))
+	assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+	// 중복은 없애고 정렬해서 저장
+	assert.Equal(t, []string{models.ScopeMessagesWrite, models.ScopeRoomsRead}, created.Scopes)
+
+	key, err := authService.APIKeys().Authenticate(created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, botID, key.UserID)
+	assert.True(t, key.HasScope(models.ScopeMessagesWrite))
+	assert.False(t, key.HasScope(models.ScopeRoomsManage))
+	assert.NotNil(t, key.LastUsedAt)
+
+	// 원문은 저장하지 않음
+	var stored models.APIKey
+	require.NoError(t, db.First(&stored, created.ID).Error)
+	assert.NotEqual(t, created.Key, stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, created.Key[len(created.Prefix):])
+}
+
+func TestAPIKeyRejectsMalformedKey(t *testing.T) {
+	_, authService := newAuthService(t)
+	_, _, created := newBotKey(t, authService, models.ScopeRoomsRead)
+
+	for name, raw := range map[string]string{
+		"empty":          "",
+		"missing prefix": strings.TrimPrefix(created.Key, "mwk_"),
+		"truncated":      created.Key[:len(created.Key)-1],
+		"display prefix": created.Prefix,
+	} {
+		_, err := authService.APIKeys().Authenticate(raw)
+		assert.ErrorIs(t, err, errors.ErrInvalidAPIKey, name)
+	}
+}
+
+func TestRevokedAPIKeyRejected(t *testing.T) {
+	_, authService := newAuthService(t)
+	ownerID, botID, created := newBotKey(t, authService, models.ScopeRoomsRead)
+
+	require.NoError(t, authService.APIKeys().RevokeKey(ownerID, botID, created.ID))
+	_, err := authService.APIKeys().Authenticate(created.Key)
+	assert.ErrorIs(t, err, errors.ErrInvalidAPIKey)
+
+	// 다시 폐기하거나 다른 사용자가 폐기할 수 없음
+	assert.ErrorIs(t, authService.APIKeys().RevokeKey(ownerID, botID, created.ID), errors.ErrAPIKeyNotFound)
+	assert.ErrorIs(t, authService.APIKeys().RevokeKey(ownerID+100, botID, created.ID), errors.ErrBotNotFound)
+}
+
+func TestDeletedBotKeyRejected(t *testing.T) {
+	db, authService := newAuthService(t)
+	ownerID, botID, created := newBotKey(t, authService, models.ScopeRoomsRead)
+
+	require.NoError(t, authService.APIKeys().DeleteBot(ownerID, botID))
+	_, err := authService.APIKeys().Authenticate(created.Key)
+	assert.ErrorIs(t, err, errors.ErrInvalidAPIKey)
+
+	// 키 폐기 없이 봇만 삭제된 경우에도 거부
+	bot, err := authService.APIKeys().CreateBot(ownerID, dto.CreateBotRequest{Username: "other-bot"})
+	require.NoError(t, err)
+	created, err = authService.APIKeys().CreateKey(ownerID, bot.ID, dto.CreateAPIKeyRequest{Name: "ci", Scopes: []string{models.ScopeRoomsRead}})
+	require.NoError(t, err)
+	require.NoError(t, db.Delete(&models.User{Model: gorm.Model{ID: bot.ID}}).Error)
+	_, err = authService.APIKeys().Authenticate(created.Key)
+	assert.ErrorIs(t, err, errors.ErrInvalidAPIKey)
+}
+
+func TestExpiredAPIKeyRejected(t *testing.T) {
+	db, authService := newAuthService(t)
+	_, _, created := newBotKey(t, authService, models.ScopeRoomsRead)
+
+	require.NoError(t, db.Model(&models.APIKey{}).Where("id = ?", created.ID).Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	_, err := authService.APIKeys().Authenticate(created.Key)
+	assert.ErrorIs(t, err, errors.ErrInvalidAPIKey)
+}
+
+func TestAPIKeyRejectsUnknownScopes(t *testing.T) {
+	_, authService := newAuthService(t)
+	ownerID, botID, _ := newBotKey(t, authService, models.ScopeRoomsRead)
+
+	for _, scopes := range [][]string{{"admin"}, {models.ScopeRoomsRead, "rooms:*"}, {""}} {
+		_, err := authService.APIKeys().CreateKey(ownerID, botID, dto.CreateAPIKeyRequest{Name: "bad", Scopes: scopes})
+		assert.ErrorIs(t, err, errors.ErrInvalidRequest, "%v", scopes)
+	}
+}
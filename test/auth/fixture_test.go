@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/events"
+	"mult-working/internal/service"
+	"mult-working/test/mocks"
+)
+
+// newAuthService는 인메모리 DB와 무작위 local 키로 AuthService를 생성합니다. configure로 설정을 바꿀 수 있습니다
+func newAuthService(t *testing.T, configure ...func(*config.AuthConfig)) (*gorm.DB, *service.AuthService) {
+	t.Helper()
+
+	db, err := mocks.NewTestDatabase()
+	require.NoError(t, err)
+
+	key, err := service.GenerateKey(service.TokenModeLocal)
+	require.NoError(t, err)
+	cfg := config.AuthConfig{
+		ActiveKeyID: "test",
+		Keys:        []config.AuthKeyConfig{{ID: "test", Secret: key.Secret}},
+	}
+	for _, fn := range configure {
+		fn(&cfg)
+	}
+
+	authService, err := service.NewAuthService(db, cfg, events.NoopPublisher{})
+	require.NoError(t, err)
+	return db, authService
+}
+
+// verifiedUserID는 액세스 토큰을 검증하고 사용자 ID를 반환합니다
+func verifiedUserID(t *testing.T, authService *service.AuthService, token string) uint {
+	t.Helper()
+	userID, err := authService.VerifyToken(token)
+	require.NoError(t, err)
+	return userID
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+	"mult-working/test/mocks"
+)
+
+const (
+	ldapServiceDN  = "cn=svc,dc=example,dc=com"
+	ldapAdminGroup = "cn=chat-admins,ou=groups,dc=example,dc=com"
+)
+
+type ldapFixture struct {
+	db          *gorm.DB
+	directory   *mocks.LDAPServer
+	authService *service.AuthService
+}
+
+func newLDAPFixture(t *testing.T) *ldapFixture {
+	t.Helper()
+
+	directory, err := mocks.NewLDAPServer(
+		mocks.LDAPEntry{DN: ldapServiceDN, Password: "svc-secret"},
+		ldapPerson("alice", "alice-pass", "alice@example.com"),
+		ldapPerson("dave", "dave-pass", "dave@example.com", ldapAdminGroup),
+	)
+	require.NoError(t, err)
+	t.Cleanup(directory.Close)
+
+	db, authService := newAuthService(t, func(cfg *config.AuthConfig) {
+		cfg.Authenticators = []string{service.AuthenticatorDatabase, service.AuthenticatorLDAP}
+		cfg.LDAP = config.LDAPConfig{
+			URL:          directory.URL(),
+			BindDN:       ldapServiceDN,
+			BindPassword: "svc-secret",
+			BaseDN:       "ou=people,dc=example,dc=com",
+			GroupRoles:   []config.LDAPGroupRole{{Group: ldapAdminGroup, Role: models.RoleAdmin}},
+		}
+	})
+
+	return &ldapFixture{db: db, directory: directory, authService: authService}
+}
+
+func ldapPerson(uid, password, mail string, groups ...string) mocks.LDAPEntry {
+	return mocks.LDAPEntry{
+		DN:       "uid=" + uid + ",ou=people,dc=example,dc=com",
+		Password: password,
+		Attributes: map[string][]string{
+			"uid":      {uid},
+			"mail":     {mail},
+			"memberOf": groups,
+		},
+	}
+}
+
+func (f *ldapFixture) login(username, password string) (*dto.LoginResponse, error) {
+	return f.authService.Login(dto.LoginRequest{Username: username, Password: password}, service.ClientInfo{IP: "127.0.0.1"})
+}
+
+func (f *ldapFixture) user(t *testing.T, resp *dto.LoginResponse) models.User {
+	t.Helper()
+	require.NotNil(t, resp.TokenResponse)
+
+	var user models.User
+	require.NoError(t, f.db.First(&user, verifiedUserID(t, f.authService, resp.Token)).Error)
+	return user
+}
+
+func TestLDAPLoginProvisionsUser(t *testing.T) {
+	f := newLDAPFixture(t)
+
+	resp, err := f.login("alice", "alice-pass")
+	require.NoError(t, err)
+
+	user := f.user(t, resp)
+	assert.Equal(t, "alice", user.Username)
+	assert.Equal(t, "alice@example.com", user.Email)
+	assert.Equal(t, models.RoleUser, user.Role)
+	assert.Empty(t, user.PasswordHash)
+
+	var identity models.UserIdentity
+	require.NoError(t, f.db.Where("provider = ? AND subject = ?", "ldap", "uid=alice,ou=people,dc=example,dc=com").First(&identity).Error)
+	assert.Equal(t, user.ID, identity.UserID)
+
+	// 검색은 서비스 계정으로, 비밀번호 확인은 사용자 DN으로 바인드
+	assert.Equal(t, []string{ldapServiceDN, "uid=alice,ou=people,dc=example,dc=com"}, f.directory.Binds())
+}
+
+func TestLDAPLoginReusesProvisionedUser(t *testing.T) {
+	f := newLDAPFixture(t)
+
+	first, err := f.login("alice", "alice-pass")
+	require.NoError(t, err)
+	second, err := f.login("alice", "alice-pass")
+	require.NoError(t, err)
+
+	assert.Equal(t, f.user(t, first).ID, f.user(t, second).ID)
+
+	var count int64
+	f.db.Model(&models.User{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestLDAPLoginRejectsInvalidCredentials(t *testing.T) {
+	f := newLDAPFixture(t)
+
+	_, err := f.login("alice", "wrong")
+	assert.ErrorIs(t, err, errors.ErrInvalidCredentials)
+
+	_, err = f.login("mallory", "alice-pass")
+	assert.ErrorIs(t, err, errors.ErrInvalidCredentials)
+
+	// 필터 주입으로 다른 항목을 찾을 수 없음
+	_, err = f.login("*", "alice-pass")
+	assert.ErrorIs(t, err, errors.ErrInvalidCredentials)
+
+	var count int64
+	f.db.Model(&models.User{}).Count(&count)
+	assert.Zero(t, count)
+}
+
+func TestLDAPAuthenticatorRejectsEmptyPassword(t *testing.T) {
+	f := newLDAPFixture(t)
+
+	authenticator, err := service.NewLDAPAuthenticator(f.db, config.LDAPConfig{
+		URL:          f.directory.URL(),
+		BindDN:       ldapServiceDN,
+		BindPassword: "svc-secret",
+		BaseDN:       "ou=people,dc=example,dc=com",
+	}, events.NoopPublisher{})
+	require.NoError(t, err)
+
+	// 빈 비밀번호는 익명 바인드로 성공하므로 디렉터리에 묻기 전에 거부
+	_, err = authenticator.Authenticate(nil, "alice", "")
+	assert.ErrorIs(t, err, errors.ErrInvalidCredentials)
+	assert.Empty(t, f.directory.Binds())
+}
+
+func TestLDAPLoginMapsGroupsToRoles(t *testing.T) {
+	f := newLDAPFixture(t)
+
+	resp, err := f.login("dave", "dave-pass")
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleAdmin, f.user(t, resp).Role)
+
+	// 그룹에서 빠지면 다음 로그인 때 역할도 내려감
+	f.directory.PutEntry(ldapPerson("dave", "dave-pass", "dave@example.com"))
+	resp, err = f.login("dave", "dave-pass")
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleUser, f.user(t, resp).Role)
+}
+
+func TestLDAPLoginLinksExistingUserByEmail(t *testing.T) {
+	f := newLDAPFixture(t)
+	require.NoError(t, f.authService.Register(dto.RegisterRequest{Username: "alice-local", Email: "alice@example.com", Password: "local-password"}))
+
+	var existing models.User
+	require.NoError(t, f.db.Where("username = ?", "alice-local").First(&existing).Error)
+
+	resp, err := f.login("alice", "alice-pass")
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, f.user(t, resp).ID)
+}
+
+func TestLDAPLoginFallsBackToDatabaseUsers(t *testing.T) {
+	f := newLDAPFixture(t)
+	require.NoError(t, f.authService.Register(dto.RegisterRequest{Username: "bob", Email: "bob@example.com", Password: "local-password"}))
+
+	resp, err := f.login("bob", "local-password")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", f.user(t, resp).Username)
+}
+
+func TestLDAPLoginReportsUnavailableDirectory(t *testing.T) {
+	f := newLDAPFixture(t)
+	require.NoError(t, f.authService.Register(dto.RegisterRequest{Username: "bob", Email: "bob@example.com", Password: "local-password"}))
+	f.directory.Close()
+
+	_, err := f.login("alice", "alice-pass")
+	assert.ErrorIs(t, err, errors.ErrAuthUnavailable)
+
+	// 디렉터리가 내려가도 로컬 사용자는 로그인 가능
+	_, err = f.login("bob", "local-password")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/service"
+)
+
+// newThrottledAuthService는 시도 횟수 제한을 바꾼 AuthService를 만들고 alice를 등록합니다
+func newThrottledAuthService(t *testing.T, throttle config.LoginThrottleConfig) *service.AuthService {
+	t.Helper()
+	_, authService := newAuthService(t, func(cfg *config.AuthConfig) {
+		cfg.LoginThrottle = throttle
+	})
+	require.NoError(t, authService.Register(dto.RegisterRequest{Username: "alice", Email: "alice@example.com", Password: "correct horse battery"}))
+	return authService
+}
+
+func attemptLogin(authService *service.AuthService, username, password, ip string) error {
+	_, err := authService.Login(dto.LoginRequest{Username: username, Password: password}, service.ClientInfo{IP: ip})
+	return err
+}
+
+// retryAfter는 제한에 걸렸으면 다시 시도할 수 있을 때까지의 시간을 반환합니다
+func retryAfter(t *testing.T, err error) time.Duration {
+	t.Helper()
+	var throttled *errors.ThrottledError
+	require.True(t, stderrors.As(err, &throttled), "expected a throttled error, got %v", err)
+	return throttled.RetryAfter
+}
+
+func TestLoginLocksAccountAfterMaxFailures(t *testing.T) {
+	authService := newThrottledAuthService(t, config.LoginThrottleConfig{
+		FreeAttempts:       3,
+		MaxAccountFailures: 3,
+		LockoutDuration:    time.Hour,
+	})
+
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, attemptLogin(authService, "alice", "wrong", "10.0.0.1"), errors.ErrInvalidCredentials)
+	}
+
+	// 잠긴 동안에는 올바른 비밀번호도, 이메일로 시도해도, 다른 IP에서도 거부
+	for _, identifier := range []string{"alice", "alice@example.com"} {
+		err := attemptLogin(authService, identifier, "correct horse battery", "10.0.0.2")
+		assert.ErrorIs(t, err, errors.ErrTooManyAttempts)
+		assert.InDelta(t, time.Hour.Seconds(), retryAfter(t, err).Seconds(), 5)
+	}
+}
+
+func TestLoginBackoffGrowsAfterFreeAttempts(t *testing.T) {
+	authService := newThrottledAuthService(t, config.LoginThrottleConfig{
+		FreeAttempts: 1,
+		BaseDelay:    time.Minute,
+		MaxDelay:     time.Hour,
+	})
+
+	assert.ErrorIs(t, attemptLogin(authService, "alice", "wrong", "10.0.0.1"), errors.ErrInvalidCredentials)
+
+	// 무료 시도를 다 쓰면 BaseDelay 대기
+	err := attemptLogin(authService, "alice", "wrong", "10.0.0.1")
+	assert.InDelta(t, time.Minute.Seconds(), retryAfter(t, err).Seconds(), 5)
+}
+
+func TestSuccessfulLoginResetsFailures(t *testing.T) {
+	authService := newThrottledAuthService(t, config.LoginThrottleConfig{
+		FreeAttempts:       3,
+		MaxAccountFailures: 3,
+		LockoutDuration:    time.Hour,
+	})
+
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, attemptLogin(authService, "alice", "wrong", "10.0.0.1"), errors.ErrInvalidCredentials)
+	}
+	require.NoError(t, attemptLogin(authService, "alice", "correct horse battery", "10.0.0.1"))
+
+	// 성공 이전의 실패는 더 이상 세지 않음
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, attemptLogin(authService, "alice", "wrong", "10.0.0.1"), errors.ErrInvalidCredentials)
+	}
+	require.NoError(t, attemptLogin(authService, "alice", "correct horse battery", "10.0.0.1"))
+}
+
+func TestLoginBlocksIPAfterMaxFailures(t *testing.T) {
+	authService := newThrottledAuthService(t, config.LoginThrottleConfig{
+		FreeAttempts:       100,
+		MaxAccountFailures: 100,
+		MaxIPFailures:      2,
+	})
+
+	// 없는 계정들로 시도해도 IP 단위로 셈
+	assert.ErrorIs(t, attemptLogin(authService, "mallory", "x", "10.0.0.66"), errors.ErrInvalidCredentials)
+	assert.ErrorIs(t, attemptLogin(authService, "trent", "x", "10.0.0.66"), errors.ErrInvalidCredentials)
+
+	assert.ErrorIs(t, attemptLogin(authService, "alice", "correct horse battery", "10.0.0.66"), errors.ErrTooManyAttempts)
+	require.NoError(t, attemptLogin(authService, "alice", "correct horse battery", "10.0.0.1"))
+}
@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+	"mult-working/test/mocks"
+)
+
+type oidcFixture struct {
+	db          *gorm.DB
+	idp         *mocks.OIDCProvider
+	authService *service.AuthService
+	oidcService *service.OIDCService
+}
+
+func newOIDCFixture(t *testing.T) *oidcFixture {
+	t.Helper()
+
+	idp, err := mocks.NewOIDCProvider("chat", "client-secret")
+	require.NoError(t, err)
+	t.Cleanup(idp.Close)
+
+	db, authService := newAuthService(t)
+
+	oidcService, err := service.NewOIDCService(db, config.OIDCConfig{
+		Providers: []config.OIDCProviderConfig{{
+			Name:         "company",
+			IssuerURL:    idp.Issuer(),
+			ClientID:     idp.ClientID,
+			ClientSecret: idp.ClientSecret,
+			RedirectURL:  "https://chat.example.com/api/auth/oidc/company/callback",
+			Scopes:       []string{"email", "profile"},
+		}},
+	}, authService, events.NoopPublisher{})
+	require.NoError(t, err)
+
+	return &oidcFixture{db: db, idp: idp, authService: authService, oidcService: oidcService}
+}
+
+// login은 로그인 시작부터 콜백까지 전체 흐름을 수행합니다
+func (f *oidcFixture) login(t *testing.T, identity mocks.OIDCIdentity) (*dto.TokenResponse, error) {
+	t.Helper()
+
+	f.idp.SetIdentity(identity)
+	authURL, err := f.oidcService.AuthCodeURL(context.Background(), "company")
+	require.NoError(t, err)
+
+	code, state, err := f.idp.Authorize(authURL)
+	require.NoError(t, err)
+	require.NotEmpty(t, code)
+
+	return f.oidcService.Callback(context.Background(), "company", state, code)
+}
+
+func (f *oidcFixture) userID(t *testing.T, resp *dto.TokenResponse) uint {
+	t.Helper()
+	return verifiedUserID(t, f.authService, resp.Token)
+}
+
+func TestOIDCAuthCodeURLUsesPKCE(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	authURL, err := f.oidcService.AuthCodeURL(context.Background(), "company")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := parsed.Query()
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.NotEmpty(t, q.Get("code_challenge"))
+	assert.NotEmpty(t, q.Get("state"))
+	assert.NotEmpty(t, q.Get("nonce"))
+	assert.Contains(t, q.Get("scope"), "openid")
+}
+
+func TestOIDCLoginProvisionsUser(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	resp, err := f.login(t, mocks.OIDCIdentity{Subject: "sub-1", Email: "alice@example.com", EmailVerified: true, PreferredUsername: "alice"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.RefreshToken)
+
+	var user models.User
+	require.NoError(t, f.db.First(&user, f.userID(t, resp)).Error)
+	assert.Equal(t, "alice", user.Username)
+	assert.Equal(t, "alice@example.com", user.Email)
+
+	var identity models.UserIdentity
+	require.NoError(t, f.db.Where("provider = ? AND subject = ?", "company", "sub-1").First(&identity).Error)
+	assert.Equal(t, user.ID, identity.UserID)
+}
+
+func TestOIDCLoginLinksExistingUserByVerifiedEmail(t *testing.T) {
+	f := newOIDCFixture(t)
+	require.NoError(t, f.authService.Register(dto.RegisterRequest{Username: "bob", Email: "bob@example.com", Password: "password"}))
+
+	var existing models.User
+	require.NoError(t, f.db.Where("username = ?", "bob").First(&existing).Error)
+
+	resp, err := f.login(t, mocks.OIDCIdentity{Subject: "sub-bob", Email: "Bob@Example.com", EmailVerified: true, PreferredUsername: "robert"})
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, f.userID(t, resp))
+}
+
+func TestOIDCLoginReusesLinkedIdentity(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	first, err := f.login(t, mocks.OIDCIdentity{Subject: "sub-2", Email: "carol@example.com", EmailVerified: true})
+	require.NoError(t, err)
+
+	// 공급자 쪽 이메일이 바뀌어도 subject로 같은 사용자에 연결
+	second, err := f.login(t, mocks.OIDCIdentity{Subject: "sub-2", Email: "carol@new.example.com", EmailVerified: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, f.userID(t, first), f.userID(t, second))
+
+	var count int64
+	f.db.Model(&models.User{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestOIDCLoginAvoidsUsernameCollision(t *testing.T) {
+	f := newOIDCFixture(t)
+	require.NoError(t, f.authService.Register(dto.RegisterRequest{Username: "dave", Email: "dave@local.example.com", Password: "password"}))
+
+	resp, err := f.login(t, mocks.OIDCIdentity{Subject: "sub-dave", Email: "dave@sso.example.com", EmailVerified: true, PreferredUsername: "dave"})
+	require.NoError(t, err)
+
+	var user models.User
+	require.NoError(t, f.db.First(&user, f.userID(t, resp)).Error)
+	assert.NotEqual(t, "dave", user.Username)
+	assert.Contains(t, user.Username, "dave-")
+}
+
+func TestOIDCLoginRejectsUnverifiedEmail(t *testing.T) {
+	f := newOIDCFixture(t)
+	require.NoError(t, f.authService.Register(dto.RegisterRequest{Username: "erin", Email: "erin@example.com", Password: "password"}))
+
+	_, err := f.login(t, mocks.OIDCIdentity{Subject: "attacker", Email: "erin@example.com", EmailVerified: false})
+	assert.ErrorIs(t, err, errors.ErrEmailNotVerified)
+
+	var count int64
+	f.db.Model(&models.UserIdentity{}).Count(&count)
+	assert.Zero(t, count)
+}
+
+func TestOIDCCallbackRejectsReplayedState(t *testing.T) {
+	f := newOIDCFixture(t)
+	f.idp.SetIdentity(mocks.OIDCIdentity{Subject: "sub-3", Email: "frank@example.com", EmailVerified: true})
+
+	authURL, err := f.oidcService.AuthCodeURL(context.Background(), "company")
+	require.NoError(t, err)
+	code, state, err := f.idp.Authorize(authURL)
+	require.NoError(t, err)
+
+	_, err = f.oidcService.Callback(context.Background(), "company", state, code)
+	require.NoError(t, err)
+
+	_, err = f.oidcService.Callback(context.Background(), "company", state, code)
+	assert.ErrorIs(t, err, errors.ErrInvalidLoginState)
+}
+
+func TestOIDCCallbackRejectsUnknownState(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	_, err := f.oidcService.Callback(context.Background(), "company", "forged", "code")
+	assert.ErrorIs(t, err, errors.ErrInvalidLoginState)
+}
+
+func TestOIDCCallbackFailsWithWrongCodeVerifier(t *testing.T) {
+	f := newOIDCFixture(t)
+	f.idp.SetIdentity(mocks.OIDCIdentity{Subject: "sub-4", Email: "grace@example.com", EmailVerified: true})
+
+	authURL, err := f.oidcService.AuthCodeURL(context.Background(), "company")
+	require.NoError(t, err)
+	code, state, err := f.idp.Authorize(authURL)
+	require.NoError(t, err)
+
+	// 저장된 verifier가 challenge와 맞지 않으면 공급자가 코드 교환을 거부
+	require.NoError(t, f.db.Model(&models.OIDCLoginState{}).Where("1 = 1").Update("code_verifier", "tampered").Error)
+
+	_, err = f.oidcService.Callback(context.Background(), "company", state, code)
+	assert.ErrorIs(t, err, errors.ErrInvalidCredentials)
+}
+
+func TestOIDCUnknownProvider(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	_, err := f.oidcService.AuthCodeURL(context.Background(), "unknown")
+	assert.ErrorIs(t, err, errors.ErrProviderNotFound)
+}
+
+func TestOIDCConfigValidation(t *testing.T) {
+	_, err := service.NewOIDCService(nil, config.OIDCConfig{
+		Providers: []config.OIDCProviderConfig{{Name: "a"}, {Name: "a", IssuerURL: "https://idp", ClientID: "x", RedirectURL: "https://cb"}},
+	}, nil, events.NoopPublisher{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "issuer_url")
+	assert.Contains(t, err.Error(), "duplicate provider")
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+	"mult-working/pkg/password"
+)
+
+// fastArgon2는 테스트가 빨리 끝나도록 낮춘 Argon2id 파라미터입니다
+var fastArgon2 = config.Argon2Config{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func TestArgon2idHashRoundTrip(t *testing.T) {
+	hasher := password.NewHasher(fastArgon2)
+
+	hash, err := hasher.Hash("correct horse battery")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"), hash)
+
+	ok, needsRehash := hasher.Verify("correct horse battery", hash)
+	assert.True(t, ok)
+	assert.False(t, needsRehash)
+
+	// 같은 비밀번호도 솔트가 달라 해시가 다름
+	other, err := hasher.Hash("correct horse battery")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
+
+func TestArgon2idRejectsWrongPasswordAndMalformedHash(t *testing.T) {
+	hasher := password.NewHasher(fastArgon2)
+	hash, err := hasher.Hash("correct horse battery")
+	require.NoError(t, err)
+
+	ok, _ := hasher.Verify("wrong horse battery", hash)
+	assert.False(t, ok)
+
+	parts := strings.Split(hash, "$")
+	for name, encoded := range map[string]string{
+		"missing part":  strings.Join(parts[:5], "$"),
+		"wrong version": strings.Replace(hash, "v=19", "v=16", 1),
+		"bad params":    strings.Replace(hash, "m=1024,t=1,p=1", "m=x,t=1,p=1", 1),
+		"bad salt":      strings.Join([]string{parts[0], parts[1], parts[2], parts[3], "!!!", parts[5]}, "$"),
+		"empty key":     strings.Join([]string{parts[0], parts[1], parts[2], parts[3], parts[4], ""}, "$"),
+		"no hash":       "",
+	} {
+		ok, needsRehash := hasher.Verify("correct horse battery", encoded)
+		assert.False(t, ok, name)
+		assert.False(t, needsRehash, name)
+	}
+}
+
+func TestVerifyLegacyBcryptHash(t *testing.T) {
+	hasher := password.NewHasher(fastArgon2)
+
+	for _, prefix := range []string{"$2a$", "$2b$"} {
+		legacy, err := bcrypt.GenerateFromPassword([]byte("correct horse battery"), bcrypt.MinCost)
+		require.NoError(t, err)
+		encoded := prefix + string(legacy)[4:]
+
+		// 맞으면 Argon2id로 다시 해시해야 함
+		ok, needsRehash := hasher.Verify("correct horse battery", encoded)
+		assert.True(t, ok, prefix)
+		assert.True(t, needsRehash, prefix)
+
+		ok, _ = hasher.Verify("wrong horse battery", encoded)
+		assert.False(t, ok, prefix)
+	}
+}
+
+func TestNeedsRehashWhenParamsChange(t *testing.T) {
+	hash, err := password.NewHasher(fastArgon2).Hash("correct horse battery")
+	require.NoError(t, err)
+
+	for name, cfg := range map[string]config.Argon2Config{
+		"memory":      {Memory: 2048, Iterations: 1, Parallelism: 1},
+		"iterations":  {Memory: 1024, Iterations: 2, Parallelism: 1},
+		"parallelism": {Memory: 1024, Iterations: 1, Parallelism: 2},
+		"salt length": {Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 32},
+		"key length":  {Memory: 1024, Iterations: 1, Parallelism: 1, KeyLength: 64},
+	} {
+		// 저장된 파라미터로 검증하므로 비밀번호는 맞음
+		ok, needsRehash := password.NewHasher(cfg).Verify("correct horse battery", hash)
+		assert.True(t, ok, name)
+		assert.True(t, needsRehash, name)
+	}
+}
+
+func TestLoginRehashesLegacyPassword(t *testing.T) {
+	db, authService := newAuthService(t, func(cfg *config.AuthConfig) {
+		cfg.Password.Argon2 = fastArgon2
+	})
+	legacy, err := bcrypt.GenerateFromPassword([]byte("correct horse battery"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := models.User{Username: "legacy", Email: "legacy@example.com", PasswordHash: string(legacy)}
+	require.NoError(t, db.Create(&user).Error)
+
+	_, err = authService.Login(dto.LoginRequest{Username: "legacy", Password: "correct horse battery"}, service.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+
+	require.NoError(t, db.First(&user, user.ID).Error)
+	assert.True(t, strings.HasPrefix(user.PasswordHash, "$argon2id$v=19$m=1024,t=1,p=1$"), user.PasswordHash)
+
+	// 새 해시로도 로그인됨
+	_, err = authService.Login(dto.LoginRequest{Username: "legacy", Password: "correct horse battery"}, service.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+}
+
+func TestLoginRehashesWhenParamsChange(t *testing.T) {
+	db, authService := newAuthService(t, func(cfg *config.AuthConfig) {
+		cfg.Password.Argon2 = fastArgon2
+	})
+	require.NoError(t, authService.Register(dto.RegisterRequest{Username: "alice", Email: "alice@example.com", Password: "correct horse battery"}))
+
+	// 같은 DB에서 파라미터만 바꿔 다시 시작
+	key, err := service.GenerateKey(service.TokenModeLocal)
+	require.NoError(t, err)
+	upgraded, err := service.NewAuthService(db, config.AuthConfig{
+		ActiveKeyID: "test",
+		Keys:        []config.AuthKeyConfig{{ID: "test", Secret: key.Secret}},
+		Password:    config.PasswordConfig{Argon2: config.Argon2Config{Memory: 2048, Iterations: 1, Parallelism: 1}},
+	}, events.NoopPublisher{})
+	require.NoError(t, err)
+
+	_, err = upgraded.Login(dto.LoginRequest{Username: "alice", Password: "correct horse battery"}, service.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+
+	var user models.User
+	require.NoError(t, db.Where("username = ?", "alice").First(&user).Error)
+	assert.True(t, strings.HasPrefix(user.PasswordHash, "$argon2id$v=19$m=2048,t=1,p=1$"), user.PasswordHash)
+}
+
+func TestPolicyRules(t *testing.T) {
+	blocklist := filepath.Join(t.TempDir(), "common-passwords.txt")
+	require.NoError(t, os.WriteFile(blocklist, []byte("# 주석\n\nPassword123\nletmein!\n"), 0o600))
+
+	policy, err := password.NewPolicy(config.PasswordConfig{MinLength: 10, MaxLength: 20, BlocklistFile: blocklist})
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		password, username string
+		valid              bool
+	}{
+		"ok":                   {"correct horse", "alice", true},
+		"too short":            {"short", "alice", false},
+		"exactly minimum":      {"0123456789", "alice", true},
+		"too long":             {strings.Repeat("a", 21), "alice", false},
+		"exactly maximum":      {strings.Repeat("a", 20), "alice", true},
+		"multibyte length":     {"비밀번호비밀번호비밀번", "alice", true},
+		"blocklisted":          {"password123", "alice", false},
+		"blocklisted any case": {"PASSWORD123", "alice", false},
+		"equals username":      {"alicealice", "AliceAlice", false},
+		"contains username":    {"my-alice-password", "alice", false},
+		"short username":       {"bob-is-fine-here", "bob", true},
+	} {
+		err := policy.Validate(tc.password, tc.username)
+		if tc.valid {
+			assert.NoError(t, err, name)
+		} else {
+			assert.ErrorIs(t, err, errors.ErrWeakPassword, name)
+		}
+	}
+}
+
+func TestPolicyMissingBlocklistFails(t *testing.T) {
+	_, err := password.NewPolicy(config.PasswordConfig{BlocklistFile: filepath.Join(t.TempDir(), "missing.txt")})
+	assert.Error(t, err)
+}
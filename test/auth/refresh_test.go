@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/service"
+)
+
+func refresh(authService *service.AuthService, refreshToken string) (*dto.TokenResponse, error) {
+	return authService.Refresh(dto.RefreshRequest{RefreshToken: refreshToken}, service.ClientInfo{IP: "127.0.0.1", UserAgent: chromeOnWindows})
+}
+
+func TestRefreshRotatesTokenPair(t *testing.T) {
+	_, authService := newAuthService(t)
+	login := loginFrom(t, authService, "alice", chromeOnWindows)
+
+	rotated, err := refresh(authService, login.RefreshToken)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, login.Token, rotated.Token)
+	assert.NotEqual(t, login.RefreshToken, rotated.RefreshToken)
+	assert.Equal(t, verifiedUserID(t, authService, login.Token), verifiedUserID(t, authService, rotated.Token))
+
+	// 새 토큰은 같은 세션에 속함
+	assert.Equal(t, sessionOf(t, authService, login.Token).SessionID, sessionOf(t, authService, rotated.Token).SessionID)
+
+	_, err = refresh(authService, rotated.RefreshToken)
+	assert.NoError(t, err)
+}
+
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	_, authService := newAuthService(t)
+	login := loginFrom(t, authService, "alice", chromeOnWindows)
+	other := loginFrom(t, authService, "alice", firefoxOnLinux)
+
+	rotated, err := refresh(authService, login.RefreshToken)
+	require.NoError(t, err)
+
+	// 이미 사용된 토큰을 다시 제출하면 탈취로 간주
+	_, err = refresh(authService, login.RefreshToken)
+	assert.ErrorIs(t, err, errors.ErrTokenReused)
+
+	// 정상 클라이언트가 받은 최신 토큰까지 패밀리 전체가 폐기됨
+	_, err = refresh(authService, rotated.RefreshToken)
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+
+	// 다른 세션의 패밀리는 그대로
+	_, err = refresh(authService, other.RefreshToken)
+	assert.NoError(t, err)
+}
+
+func TestConcurrentRefreshSucceedsOnce(t *testing.T) {
+	_, authService := newAuthService(t)
+	login := loginFrom(t, authService, "alice", chromeOnWindows)
+
+	const attempts = 5
+	var wg sync.WaitGroup
+	results := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := refresh(authService, login.RefreshToken)
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		// 늦게 온 요청은 재사용으로 감지되거나 이미 폐기된 패밀리로 거부됨
+		if err != errors.ErrTokenReused {
+			assert.ErrorIs(t, err, errors.ErrInvalidToken)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+}
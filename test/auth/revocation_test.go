@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/service"
+)
+
+func TestRevokeAllSessionsRejectsEarlierTokens(t *testing.T) {
+	_, authService := newAuthService(t)
+
+	first := loginFrom(t, authService, "alice", chromeOnWindows)
+	second := loginFrom(t, authService, "alice", firefoxOnLinux)
+	userID := verifiedUserID(t, authService, first.Token)
+
+	require.NoError(t, authService.RevokeAllSessions(userID))
+
+	for _, resp := range []*dto.TokenResponse{first, second} {
+		_, err := authService.VerifyToken(resp.Token)
+		assert.ErrorIs(t, err, errors.ErrTokenRevoked)
+
+		_, err = authService.Refresh(dto.RefreshRequest{RefreshToken: resp.RefreshToken}, service.ClientInfo{})
+		assert.ErrorIs(t, err, errors.ErrInvalidToken)
+	}
+}
+
+func TestLoginRightAfterRevokeAllIsAccepted(t *testing.T) {
+	_, authService := newAuthService(t)
+
+	old := loginFrom(t, authService, "alice", chromeOnWindows)
+	userID := verifiedUserID(t, authService, old.Token)
+
+	// 전체 폐기 직후(같은 초 안) 다시 로그인한 토큰은 유효해야 함
+	require.NoError(t, authService.RevokeAllSessions(userID))
+	fresh := loginFrom(t, authService, "alice", chromeOnWindows)
+
+	assert.Equal(t, userID, verifiedUserID(t, authService, fresh.Token))
+	_, err := authService.VerifyToken(old.Token)
+	assert.ErrorIs(t, err, errors.ErrTokenRevoked)
+}
+
+func TestLogoutRevokesOnlyThatSession(t *testing.T) {
+	_, authService := newAuthService(t)
+
+	laptop := loginFrom(t, authService, "alice", chromeOnWindows)
+	phone := loginFrom(t, authService, "alice", firefoxOnLinux)
+
+	// 캐시된 "유효" 판정이 폐기 후에도 남아 있으면 안 됨
+	claims := sessionOf(t, authService, laptop.Token)
+	require.NoError(t, authService.Logout(claims, laptop.RefreshToken))
+
+	_, err := authService.VerifyToken(laptop.Token)
+	assert.ErrorIs(t, err, errors.ErrTokenRevoked)
+	_, err = authService.Refresh(dto.RefreshRequest{RefreshToken: laptop.RefreshToken}, service.ClientInfo{})
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+
+	assert.Equal(t, claims.UserID, verifiedUserID(t, authService, phone.Token))
+}
+
+func TestRevokeUnknownUserFails(t *testing.T) {
+	_, authService := newAuthService(t)
+	assert.ErrorIs(t, authService.RevokeAllSessions(9999), errors.ErrUserNotFound)
+}
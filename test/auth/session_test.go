@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/service"
+)
+
+const (
+	chromeOnWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36"
+	firefoxOnLinux  = "Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0"
+)
+
+// loginFrom은 사용자를 등록하고(처음 한 번) 주어진 기기에서 로그인합니다
+func loginFrom(t *testing.T, authService *service.AuthService, username, userAgent string) *dto.TokenResponse {
+	t.Helper()
+
+	err := authService.Register(dto.RegisterRequest{Username: username, Email: username + "@example.com", Password: "correct horse battery"})
+	if err != nil {
+		require.ErrorIs(t, err, errors.ErrUserExists)
+	}
+
+	resp, err := authService.Login(dto.LoginRequest{Username: username, Password: "correct horse battery"}, service.ClientInfo{IP: "127.0.0.1", UserAgent: userAgent})
+	require.NoError(t, err)
+	require.NotNil(t, resp.TokenResponse)
+	return resp.TokenResponse
+}
+
+func sessionOf(t *testing.T, authService *service.AuthService, token string) *service.TokenClaims {
+	t.Helper()
+	claims, err := authService.VerifyTokenClaims(token)
+	require.NoError(t, err)
+	require.NotEmpty(t, claims.SessionID)
+	return claims
+}
+
+func TestListSessionsShowsEachDevice(t *testing.T) {
+	_, authService := newAuthService(t)
+
+	desktop := loginFrom(t, authService, "alice", chromeOnWindows)
+	loginFrom(t, authService, "alice", firefoxOnLinux)
+
+	current := sessionOf(t, authService, desktop.Token)
+	sessions, err := authService.ListSessions(current.UserID, current.SessionID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	devices := map[string]bool{}
+	for _, session := range sessions {
+		devices[session.Device] = session.Current
+		assert.Equal(t, "127.0.0.1", session.IP)
+	}
+	assert.Equal(t, map[string]bool{"Chrome on Windows": true, "Firefox on Linux": false}, devices)
+}
+
+func TestRevokeSessionKillsAccessTokenAndRefreshFamily(t *testing.T) {
+	_, authService := newAuthService(t)
+
+	laptop := loginFrom(t, authService, "alice", chromeOnWindows)
+	phone := loginFrom(t, authService, "alice", firefoxOnLinux)
+
+	// 한 번 회전한 뒤의 리프레시 토큰도 같은 패밀리
+	rotated, err := authService.Refresh(dto.RefreshRequest{RefreshToken: laptop.RefreshToken}, service.ClientInfo{})
+	require.NoError(t, err)
+
+	claims := sessionOf(t, authService, laptop.Token)
+	require.NoError(t, authService.RevokeSession(claims.UserID, claims.SessionID))
+
+	// 세션의 액세스 토큰은 회전 전후 모두 거부
+	_, err = authService.VerifyToken(laptop.Token)
+	assert.ErrorIs(t, err, errors.ErrTokenRevoked)
+	_, err = authService.VerifyToken(rotated.Token)
+	assert.ErrorIs(t, err, errors.ErrTokenRevoked)
+
+	// 세션의 리프레시 토큰 패밀리도 폐기
+	_, err = authService.Refresh(dto.RefreshRequest{RefreshToken: rotated.RefreshToken}, service.ClientInfo{})
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+
+	// 다른 기기의 세션은 그대로
+	assert.Equal(t, claims.UserID, verifiedUserID(t, authService, phone.Token))
+	_, err = authService.Refresh(dto.RefreshRequest{RefreshToken: phone.RefreshToken}, service.ClientInfo{IP: "127.0.0.1", UserAgent: firefoxOnLinux})
+	assert.NoError(t, err)
+
+	sessions, err := authService.ListSessions(claims.UserID, "")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "Firefox on Linux", sessions[0].Device)
+}
+
+func TestRevokeSessionRejectsOtherUsersSession(t *testing.T) {
+	_, authService := newAuthService(t)
+
+	alice := sessionOf(t, authService, loginFrom(t, authService, "alice", chromeOnWindows).Token)
+	bob := sessionOf(t, authService, loginFrom(t, authService, "bob", chromeOnWindows).Token)
+
+	assert.ErrorIs(t, authService.RevokeSession(bob.UserID, alice.SessionID), errors.ErrSessionNotFound)
+	assert.ErrorIs(t, authService.RevokeSession(alice.UserID, "unknown"), errors.ErrSessionNotFound)
+}
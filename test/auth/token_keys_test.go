@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/o1egl/paseto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/config"
+	"mult-working/internal/events"
+	"mult-working/internal/service"
+	"mult-working/test/mocks"
+)
+
+func TestLegacySymmetricKeyVerifiesOldTokens(t *testing.T) {
+	const legacySecret = "your-32-byte-secret-key-here-12345678"
+	_, authService := newAuthService(t, func(cfg *config.AuthConfig) {
+		cfg.SymmetricKey = legacySecret
+	})
+	// 새 토큰은 설정된 키로 계속 발급
+	resp := loginFrom(t, authService, "legacy-user", chromeOnWindows)
+	userID := verifiedUserID(t, authService, resp.Token)
+
+	// 이전 버전 형식: 키를 32바이트로 자르고 푸터 없이 발급
+	legacyKey := make([]byte, 32)
+	copy(legacyKey, legacySecret)
+	now := time.Now()
+	token, err := paseto.NewV2().Encrypt(legacyKey, map[string]interface{}{
+		"user_id":    userID,
+		"issued_at":  now.Unix(),
+		"expires_at": now.Add(time.Minute).Unix(),
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, userID, verifiedUserID(t, authService, token))
+}
+
+func TestMissingKeysFailOutsideDevMode(t *testing.T) {
+	db, err := mocks.NewTestDatabase()
+	require.NoError(t, err)
+
+	_, err = service.NewAuthService(db, config.AuthConfig{}, events.NoopPublisher{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "auth.keys")
+}
+
+func TestMissingKeysUseEphemeralKeyInDevMode(t *testing.T) {
+	db, err := mocks.NewTestDatabase()
+	require.NoError(t, err)
+
+	authService, err := service.NewAuthService(db, config.AuthConfig{DevMode: true}, events.NoopPublisher{})
+	require.NoError(t, err)
+
+	resp := loginFrom(t, authService, "dev-user", chromeOnWindows)
+	verifiedUserID(t, authService, resp.Token)
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/config"
+	"mult-working/internal/service"
+	"mult-working/pkg/tokenverify"
+)
+
+func TestTokenVerifyAcceptsServiceTokens(t *testing.T) {
+	key, err := service.GenerateKey(service.TokenModePublic)
+	require.NoError(t, err)
+	_, authService := newAuthService(t, func(cfg *config.AuthConfig) {
+		cfg.TokenMode = service.TokenModePublic
+		cfg.ActiveKeyID = "signing"
+		cfg.Keys = []config.AuthKeyConfig{{ID: "signing", PrivateKey: key.PrivateKey}}
+	})
+
+	// /api/auth/keys와 같은 응답
+	keys := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": authService.PublicKeys()})
+	}))
+	t.Cleanup(keys.Close)
+
+	// 설정 파일의 공개 키와 같은 인코딩으로 제공
+	require.Len(t, authService.PublicKeys(), 1)
+	assert.Equal(t, key.PublicKey, authService.PublicKeys()[0].Key)
+
+	resp := loginFrom(t, authService, "alice", chromeOnWindows)
+	claims, err := tokenverify.New(keys.URL).Verify(resp.Token)
+	require.NoError(t, err)
+	assert.Equal(t, verifiedUserID(t, authService, resp.Token), claims.UserID)
+}
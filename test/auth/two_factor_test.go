@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/service"
+	"mult-working/pkg/totp"
+)
+
+// rfc6238Secret은 RFC 6238 부록 B의 SHA1 키 "12345678901234567890"을 base32로 인코딩한 값입니다
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPMatchesRFC6238Vectors(t *testing.T) {
+	// RFC의 8자리 코드 중 뒤 6자리
+	for unix, want := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := totp.Code(rfc6238Secret, totp.Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "T=%d", unix)
+	}
+}
+
+func TestTOTPValidateSkewWindow(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := totp.Step(now)
+
+	for offset := int64(-2); offset <= 2; offset++ {
+		code, err := totp.Code(rfc6238Secret, current+offset)
+		require.NoError(t, err)
+
+		step, ok := totp.Validate(rfc6238Secret, code, now, 1)
+		if offset < -1 || offset > 1 {
+			assert.False(t, ok, "offset %d", offset)
+			continue
+		}
+		assert.True(t, ok, "offset %d", offset)
+		assert.Equal(t, current+offset, step)
+	}
+
+	// 공백은 무시하고, 자릿수가 다르면 거부
+	code, err := totp.Code(rfc6238Secret, current)
+	require.NoError(t, err)
+	_, ok := totp.Validate(rfc6238Secret, code[:3]+" "+code[3:], now, 1)
+	assert.True(t, ok)
+	_, ok = totp.Validate(rfc6238Secret, code[:5], now, 1)
+	assert.False(t, ok)
+}
+
+// enableTwoFactor는 사용자의 2단계 인증을 켜고 비밀 키와 복구 코드를 반환합니다
+func enableTwoFactor(t *testing.T, authService *service.AuthService, userID uint) (string, []string) {
+	t.Helper()
+	enrollment, err := authService.TwoFactor().Enroll(userID)
+	require.NoError(t, err)
+
+	code, err := totp.Code(enrollment.Secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	recoveryCodes, err := authService.TwoFactor().Confirm(userID, code)
+	require.NoError(t, err)
+	require.NotEmpty(t, recoveryCodes)
+	return enrollment.Secret, recoveryCodes
+}
+
+func passwordLogin(t *testing.T, authService *service.AuthService, username string) *dto.LoginResponse {
+	t.Helper()
+	resp, err := authService.Login(dto.LoginRequest{Username: username, Password: "correct horse battery"}, service.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestTOTPCodeCannotBeReused(t *testing.T) {
+	_, authService := newAuthService(t)
+	userID := verifiedUserID(t, authService, loginFrom(t, authService, "alice", chromeOnWindows).Token)
+	enrollment, err := authService.TwoFactor().Enroll(userID)
+	require.NoError(t, err)
+
+	// 등록 확인에 쓴 코드는 이미 사용됨
+	step := totp.Step(time.Now())
+	code, err := totp.Code(enrollment.Secret, step)
+	require.NoError(t, err)
+	_, err = authService.TwoFactor().Confirm(userID, code)
+	require.NoError(t, err)
+	assert.ErrorIs(t, authService.TwoFactor().Verify(userID, code), errors.ErrInvalidTwoFactor)
+
+	// 허용 오차 안의 다음 단계 코드는 한 번만 통과하고, 그 뒤로는 이전 단계 코드도 거부
+	next, err := totp.Code(enrollment.Secret, step+1)
+	require.NoError(t, err)
+	require.NoError(t, authService.TwoFactor().Verify(userID, next))
+	assert.ErrorIs(t, authService.TwoFactor().Verify(userID, next), errors.ErrInvalidTwoFactor)
+}
+
+func TestLoginReturnsChallengeWhenTwoFactorEnabled(t *testing.T) {
+	_, authService := newAuthService(t)
+	userID := verifiedUserID(t, authService, loginFrom(t, authService, "alice", chromeOnWindows).Token)
+	secret, _ := enableTwoFactor(t, authService, userID)
+
+	resp := passwordLogin(t, authService, "alice")
+	assert.True(t, resp.TwoFactorRequired)
+	assert.Nil(t, resp.TokenResponse)
+	require.NotEmpty(t, resp.ChallengeToken)
+	require.NotNil(t, resp.ChallengeExpiresAt)
+
+	// 챌린지 토큰은 액세스 토큰으로 쓸 수 없음
+	_, err := authService.VerifyToken(resp.ChallengeToken)
+	assert.Error(t, err)
+
+	code, err := totp.Code(secret, totp.Step(time.Now())+1)
+	require.NoError(t, err)
+	tokens, err := authService.LoginTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: resp.ChallengeToken, Code: code}, service.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, userID, verifiedUserID(t, authService, tokens.Token))
+}
+
+func TestRecoveryCodeWorksOnce(t *testing.T) {
+	_, authService := newAuthService(t)
+	userID := verifiedUserID(t, authService, loginFrom(t, authService, "alice", chromeOnWindows).Token)
+	_, recoveryCodes := enableTwoFactor(t, authService, userID)
+
+	// 대소문자와 구분자는 무시
+	challenge := passwordLogin(t, authService, "alice").ChallengeToken
+	entered := strings.ToUpper(strings.ReplaceAll(recoveryCodes[0], "-", " "))
+	_, err := authService.LoginTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: challenge, Code: entered}, service.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+
+	challenge = passwordLogin(t, authService, "alice").ChallengeToken
+	_, err = authService.LoginTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: challenge, Code: recoveryCodes[0]}, service.ClientInfo{IP: "127.0.0.1"})
+	assert.ErrorIs(t, err, errors.ErrInvalidTwoFactor)
+
+	// 다른 복구 코드는 그대로 사용 가능
+	_, err = authService.LoginTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: challenge, Code: recoveryCodes[1]}, service.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+}
+
+func TestLoginTwoFactorRejectsInvalidChallenge(t *testing.T) {
+	_, authService := newAuthService(t)
+	access := loginFrom(t, authService, "alice", chromeOnWindows).Token
+	userID := verifiedUserID(t, authService, access)
+	_, recoveryCodes := enableTwoFactor(t, authService, userID)
+
+	for name, challenge := range map[string]string{
+		"garbage":      "not-a-token",
+		"access token": access,
+	} {
+		_, err := authService.LoginTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: challenge, Code: recoveryCodes[0]}, service.ClientInfo{IP: "127.0.0.1"})
+		assert.ErrorIs(t, err, errors.ErrInvalidToken, name)
+	}
+
+	// 다른 키로 발급된 챌린지
+	_, other := newAuthService(t)
+	otherUserID := verifiedUserID(t, other, loginFrom(t, other, "alice", chromeOnWindows).Token)
+	enableTwoFactor(t, other, otherUserID)
+	foreign := passwordLogin(t, other, "alice").ChallengeToken
+	_, err := authService.LoginTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: foreign, Code: recoveryCodes[0]}, service.ClientInfo{IP: "127.0.0.1"})
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+
+	// 거부된 챌린지는 복구 코드를 소모하지 않음
+	challenge := passwordLogin(t, authService, "alice").ChallengeToken
+	_, err = authService.LoginTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: challenge, Code: recoveryCodes[0]}, service.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+}
+
+func TestLoginTwoFactorRejectsExpiredChallenge(t *testing.T) {
+	_, authService := newAuthService(t, func(cfg *config.AuthConfig) {
+		cfg.TwoFactorChallengeDuration = time.Millisecond
+	})
+	userID := verifiedUserID(t, authService, loginFrom(t, authService, "alice", chromeOnWindows).Token)
+	_, recoveryCodes := enableTwoFactor(t, authService, userID)
+
+	// 만료는 초 단위로 비교
+	challenge := passwordLogin(t, authService, "alice").ChallengeToken
+	time.Sleep(1100 * time.Millisecond)
+
+	_, err := authService.LoginTwoFactor(dto.TwoFactorLoginRequest{ChallengeToken: challenge, Code: recoveryCodes[0]}, service.ClientInfo{IP: "127.0.0.1"})
+	assert.ErrorIs(t, err, errors.ErrTokenExpired)
+}
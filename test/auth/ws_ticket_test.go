@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/config"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+)
+
+func TestWSQueryTokenRejectedByDefault(t *testing.T) {
+	_, authService := newAuthService(t)
+	resp := loginFrom(t, authService, "alice", chromeOnWindows)
+
+	_, err := authService.VerifyWSQueryToken(resp.Token)
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+}
+
+func TestWSQueryTokenAcceptedWhenAllowed(t *testing.T) {
+	_, authService := newAuthService(t, func(cfg *config.AuthConfig) {
+		cfg.AllowWSQueryToken = true
+	})
+	resp := loginFrom(t, authService, "alice", chromeOnWindows)
+
+	claims, err := authService.VerifyWSQueryToken(resp.Token)
+	require.NoError(t, err)
+	assert.Equal(t, verifiedUserID(t, authService, resp.Token), claims.UserID)
+
+	// 폐기된 토큰은 플래그와 관계없이 거부
+	require.NoError(t, authService.RevokeAllSessions(claims.UserID))
+	_, err = authService.VerifyWSQueryToken(resp.Token)
+	assert.Error(t, err)
+}
+
+// issueTicket은 alice로 로그인해 127.0.0.1에서 쓸 웹소켓 티켓을 발급합니다
+func issueTicket(t *testing.T, authService *service.AuthService) (*service.TokenClaims, string) {
+	t.Helper()
+	resp := loginFrom(t, authService, "alice", chromeOnWindows)
+	claims, err := authService.VerifyTokenClaims(resp.Token)
+	require.NoError(t, err)
+	ticket, err := authService.IssueWSTicket(claims, "127.0.0.1")
+	require.NoError(t, err)
+	return claims, ticket.Ticket
+}
+
+func TestWSTicketRedeemsOnce(t *testing.T) {
+	_, authService := newAuthService(t)
+	claims, ticket := issueTicket(t, authService)
+
+	redeemed, err := authService.RedeemWSTicket(ticket, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, claims.UserID, redeemed.UserID)
+	assert.Equal(t, claims.TokenID, redeemed.TokenID)
+	assert.Equal(t, claims.SessionID, redeemed.SessionID)
+	assert.Equal(t, claims.ExpiresAt, redeemed.ExpiresAt)
+
+	_, err = authService.RedeemWSTicket(ticket, "127.0.0.1")
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+}
+
+func TestWSTicketBoundToIssuingIP(t *testing.T) {
+	_, authService := newAuthService(t)
+	_, ticket := issueTicket(t, authService)
+
+	_, err := authService.RedeemWSTicket(ticket, "10.0.0.9")
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+
+	// 거부된 티켓도 사용 처리됨
+	_, err = authService.RedeemWSTicket(ticket, "127.0.0.1")
+	assert.ErrorIs(t, err, errors.ErrInvalidToken)
+}
+
+func TestWSTicketExpires(t *testing.T) {
+	_, authService := newAuthService(t, func(cfg *config.AuthConfig) {
+		cfg.WSTicketDuration = time.Millisecond
+	})
+	_, ticket := issueTicket(t, authService)
+	time.Sleep(10 * time.Millisecond)
+
+	_, err := authService.RedeemWSTicket(ticket, "127.0.0.1")
+	assert.ErrorIs(t, err, errors.ErrTokenExpired)
+}
+
+func TestWSTicketKeepsTokenScopes(t *testing.T) {
+	_, authService := newAuthService(t)
+	claims, _ := issueTicket(t, authService)
+
+	// 게스트 토큰처럼 권한 범위가 있는 토큰으로 발급
+	claims.Scopes = []string{models.ScopeRoomsRead, models.ScopeMessagesWrite}
+	ticket, err := authService.IssueWSTicket(claims, "127.0.0.1")
+	require.NoError(t, err)
+
+	redeemed, err := authService.RedeemWSTicket(ticket.Ticket, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, claims.Scopes, redeemed.Scopes)
+}
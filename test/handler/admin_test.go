@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/dto"
+	"mult-working/internal/models"
+)
+
+func (s *server) setRole(t *testing.T, userID uint, role string) {
+	t.Helper()
+	require.NoError(t, s.db.Model(&models.User{}).Where("id = ?", userID).Update("role", role).Error)
+}
+
+func TestRequireRoleChecksSystemRole(t *testing.T) {
+	s := newServer(t)
+	_, userToken := s.userToken(t, "alice")
+	modID, modToken := s.userToken(t, "mod")
+	adminID, adminToken := s.userToken(t, "root")
+	s.setRole(t, modID, models.RoleModerator)
+	s.setRole(t, adminID, models.RoleAdmin)
+	setRole := fmt.Sprintf("/api/admin/users/%d/role", modID)
+
+	assert.Equal(t, http.StatusUnauthorized, s.do(t, http.MethodGet, "/api/admin/users", "", nil).Code)
+	assert.Equal(t, http.StatusForbidden, s.do(t, http.MethodGet, "/api/admin/users", "Bearer "+userToken, nil).Code)
+
+	// 조회는 모더레이터 이상, 변경은 관리자만
+	assert.Equal(t, http.StatusOK, s.do(t, http.MethodGet, "/api/admin/users", "Bearer "+modToken, nil).Code)
+	assert.Equal(t, http.StatusForbidden, s.do(t, http.MethodPut, setRole, "Bearer "+modToken, dto.UpdateRoleRequest{Role: models.RoleAdmin}).Code)
+
+	rec := s.do(t, http.MethodPut, setRole, "Bearer "+adminToken, dto.UpdateRoleRequest{Role: models.RoleUser})
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// 역할은 토큰에 담기지 않으므로 강등은 기존 토큰에도 바로 적용됨
+	assert.Equal(t, http.StatusForbidden, s.do(t, http.MethodGet, "/api/admin/users", "Bearer "+modToken, nil).Code)
+}
+
+func TestRequireRoleRejectsAPIKeys(t *testing.T) {
+	s := newServer(t)
+	adminID, _ := s.userToken(t, "root")
+	s.setRole(t, adminID, models.RoleAdmin)
+	key := s.botKey(t, adminID, "admin-bot", models.ScopeRoomsRead, models.ScopeRoomsManage)
+
+	assert.Equal(t, http.StatusForbidden, s.do(t, http.MethodGet, "/api/admin/users", key, nil).Code)
+}
+
+func TestAdminCannotDemoteLastAdmin(t *testing.T) {
+	s := newServer(t)
+	adminID, adminToken := s.userToken(t, "root")
+	s.setRole(t, adminID, models.RoleAdmin)
+
+	rec := s.do(t, http.MethodPut, fmt.Sprintf("/api/admin/users/%d/role", adminID), "Bearer "+adminToken, dto.UpdateRoleRequest{Role: models.RoleUser})
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+}
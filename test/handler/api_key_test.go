@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+// botKey는 ownerID가 관리하는 봇과 지정된 권한 범위의 키를 만들고 Authorization 헤더 값을 반환합니다
+func (s *server) botKey(t *testing.T, ownerID uint, name string, scopes ...string) string {
+	t.Helper()
+	bot, err := s.authService.APIKeys().CreateBot(ownerID, dto.CreateBotRequest{Username: name})
+	require.NoError(t, err)
+	key, err := s.authService.APIKeys().CreateKey(ownerID, bot.ID, dto.CreateAPIKeyRequest{Name: "ci", Scopes: scopes})
+	require.NoError(t, err)
+	return "ApiKey " + key.Key
+}
+
+func TestMalformedAPIKeyHeaderRejected(t *testing.T) {
+	s := newServer(t)
+	ownerID, _ := s.userToken(t, "alice")
+	valid := s.botKey(t, ownerID, "reader-bot", models.ScopeRoomsRead)
+
+	for _, header := range []string{"ApiKey", "ApiKey ", "ApiKey not-a-key", "Apikey " + valid[len("ApiKey "):], "Bearer " + valid[len("ApiKey "):]} {
+		rec := s.do(t, http.MethodGet, "/api/protected/rooms", header, nil)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, header)
+	}
+
+	rec := s.do(t, http.MethodGet, "/api/protected/rooms", valid, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireScopeRejectsKeyWithoutScope(t *testing.T) {
+	s := newServer(t)
+	ownerID, _ := s.userToken(t, "alice")
+	reader := s.botKey(t, ownerID, "reader-bot", models.ScopeRoomsRead)
+
+	rec := s.do(t, http.MethodPost, "/api/protected/rooms", reader, dto.CreateRoomRequest{Name: "bots only"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), errors.ErrInsufficientScope.Error())
+
+	rec = s.do(t, http.MethodPost, "/api/protected/messages", reader, map[string]interface{}{"roomId": 1, "content": "hi"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), errors.ErrInsufficientScope.Error())
+}
+
+func TestRoomsManageScopeAllowsRoomAdministration(t *testing.T) {
+	s := newServer(t)
+	ownerID, _ := s.userToken(t, "alice")
+	manager := s.botKey(t, ownerID, "manager-bot", models.ScopeRoomsRead, models.ScopeRoomsManage)
+	reader := s.botKey(t, ownerID, "reader-bot", models.ScopeRoomsRead)
+
+	// 봇이 만든 채팅방은 봇이 소유자
+	rec := s.do(t, http.MethodPost, "/api/protected/rooms", manager, dto.CreateRoomRequest{Name: "deployments"})
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var room dto.RoomResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &room))
+	path := fmt.Sprintf("/api/protected/rooms/%d", room.ID)
+
+	topic := "release train"
+	rec = s.do(t, http.MethodPatch, path, reader, dto.UpdateRoomRequest{Topic: &topic})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), errors.ErrInsufficientScope.Error())
+
+	rec = s.do(t, http.MethodPatch, path, manager, dto.UpdateRoomRequest{Topic: &topic})
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = s.do(t, http.MethodPost, path+"/archive", manager, nil)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = s.do(t, http.MethodPost, path+"/unarchive", manager, nil)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = s.do(t, http.MethodDelete, path, reader, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), errors.ErrInsufficientScope.Error())
+	rec = s.do(t, http.MethodDelete, path, manager, nil)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
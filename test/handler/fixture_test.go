@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/events"
+	"mult-working/internal/handler"
+	"mult-working/internal/service"
+	"mult-working/test/mocks"
+)
+
+type server struct {
+	db          *gorm.DB
+	authService *service.AuthService
+	router      *gin.Engine
+}
+
+// newServer는 인메모리 DB와 Kafka 목으로 전체 라우트를 구성합니다
+func newServer(t *testing.T) *server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, err := mocks.NewTestDatabase()
+	require.NoError(t, err)
+
+	key, err := service.GenerateKey(service.TokenModeLocal)
+	require.NoError(t, err)
+	cfg := config.AuthConfig{
+		ActiveKeyID: "test",
+		Keys:        []config.AuthKeyConfig{{ID: "test", Secret: key.Secret}},
+	}
+	publisher := events.NoopPublisher{}
+	authService, err := service.NewAuthService(db, cfg, publisher)
+	require.NoError(t, err)
+	oidcService, err := service.NewOIDCService(db, cfg.OIDC, authService, publisher)
+	require.NoError(t, err)
+	accountService := service.NewAccountService(db, cfg, authService, &mocks.Mailer{})
+
+	h := handler.NewHandler(db, mocks.NewKafkaClient(), authService, oidcService, accountService, publisher)
+	t.Cleanup(func() { h.Close() })
+
+	router := gin.New()
+	h.SetupRoutes(router)
+	return &server{db: db, authService: authService, router: router}
+}
+
+// userToken은 사용자를 등록하고 로그인해 사용자 ID와 액세스 토큰을 반환합니다
+func (s *server) userToken(t *testing.T, username string) (uint, string) {
+	t.Helper()
+	require.NoError(t, s.authService.Register(dto.RegisterRequest{Username: username, Email: username + "@example.com", Password: "correct horse battery"}))
+	resp, err := s.authService.Login(dto.LoginRequest{Username: username, Password: "correct horse battery"}, service.ClientInfo{IP: "127.0.0.1"})
+	require.NoError(t, err)
+	userID, err := s.authService.VerifyToken(resp.Token)
+	require.NoError(t, err)
+	return userID, resp.Token
+}
+
+// do는 Authorization 헤더와 JSON 본문으로 요청을 보냅니다
+func (s *server) do(t *testing.T, method, path, authorization string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	return rec
+}
@@ -1,7 +1,7 @@
 package mocks
 
 import (
-	"mult-working/internal/models"
+	"mult-working/pkg/database"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -14,11 +14,17 @@ func NewTestDatabase() (*gorm.DB, error) {
 		return nil, err
 	}
 
-	// 테스트용 스키마 마이그레이션
-	err = db.AutoMigrate(&models.User{}, &models.Message{})
+	// 인메모리 DB는 연결마다 따로 생성되므로 연결을 하나로 제한
+	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, err
 	}
+	sqlDB.SetMaxOpenConns(1)
+
+	// 테스트용 스키마 마이그레이션
+	if err := database.DBAutoMigrate(db); err != nil {
+		return nil, err
+	}
 
 	return db, nil
 }
@@ -3,14 +3,19 @@ package mocks
 import (
 	"context"
 	"mult-working/pkg/kafka"
+	"sync"
 )
 
 type KafkaClient struct {
+	mu       sync.Mutex
 	Messages map[string][]string
+	handlers []kafka.MessageHandler
 }
 
 // 인터페이스 구현 확인
 var _ kafka.KafkaInterface = (*KafkaClient)(nil)
+var _ kafka.Producer = kafkaCloser{}
+var _ kafka.Consumer = kafkaCloser{}
 
 func NewKafkaClient() *KafkaClient {
 	return &KafkaClient{
@@ -18,18 +23,69 @@ func NewKafkaClient() *KafkaClient {
 	}
 }
 
-func (m *KafkaClient) Publish(ctx context.Context, key, value string) error {
+// Produce는 메시지를 토픽별로 저장하고 등록된 핸들러에 즉시 전달합니다
+func (m *KafkaClient) Produce(topic string, value []byte) error {
+	return m.ProduceWithKey(topic, nil, value)
+}
+
+func (m *KafkaClient) ProduceWithKey(topic string, key, value []byte) error {
+	m.mu.Lock()
 	if m.Messages == nil {
 		m.Messages = make(map[string][]string)
 	}
-	m.Messages[key] = append(m.Messages[key], value)
+	m.Messages[topic] = append(m.Messages[topic], string(value))
+	handlers := append([]kafka.MessageHandler(nil), m.handlers...)
+	m.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *KafkaClient) AddHandler(handler kafka.MessageHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers = append(m.handlers, handler)
+}
+
+func (m *KafkaClient) Start(ctx context.Context) error {
+	return nil
+}
+
+func (m *KafkaClient) Stop(ctx context.Context) error {
 	return nil
 }
 
-func (m *KafkaClient) Subscribe(ctx context.Context) (string, error) {
-	return "", nil
+func (m *KafkaClient) Consume(ctx context.Context) ([]byte, error) {
+	return nil, nil
 }
 
 func (m *KafkaClient) Close() error {
 	return nil
 }
+
+func (m *KafkaClient) Stats() kafka.Stats {
+	return kafka.Stats{BrokerReachable: true}
+}
+
+func (m *KafkaClient) CheckHealth() error {
+	return nil
+}
+
+func (m *KafkaClient) GetProducer() kafka.Producer {
+	return kafkaCloser{m}
+}
+
+func (m *KafkaClient) GetConsumer() kafka.Consumer {
+	return kafkaCloser{m}
+}
+
+// kafkaCloser는 Close의 시그니처 차이(Producer/Consumer는 반환값 없음)를 맞춰줍니다
+type kafkaCloser struct {
+	*KafkaClient
+}
+
+func (k kafkaCloser) Close() {}
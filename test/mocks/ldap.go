@@ -0,0 +1,320 @@
+package mocks
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+// LDAP 프로토콜 연산 태그 (RFC 4511)
+const (
+	ldapBindRequest      = 0
+	ldapBindResponse     = 1
+	ldapUnbindRequest    = 2
+	ldapSearchRequest    = 3
+	ldapSearchResultItem = 4
+	ldapSearchResultDone = 5
+)
+
+// LDAP 결과 코드
+const (
+	ldapSuccess                 = 0
+	ldapSizeLimitExceeded       = 4
+	ldapInvalidCredentials      = 49
+	ldapInsufficientAccessRight = 50
+	ldapUnwillingToPerform      = 53
+)
+
+// LDAPEntry는 스텁 디렉터리의 항목입니다. Password가 있는 항목만 바인드할 수 있습니다
+type LDAPEntry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// LDAPServer는 테스트용 인프로세스 LDAP 서버 스텁입니다.
+// 단순 바인드와 검색(and/or/not, 일치, 존재 필터)만 지원하며, 검색은 바인드한 연결에서만 허용합니다
+type LDAPServer struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	entries []LDAPEntry
+	binds   []string
+	conns   map[net.Conn]struct{}
+	wg      sync.WaitGroup
+}
+
+// NewLDAPServer는 임의의 로컬 포트에서 스텁 서버를 시작합니다. 테스트가 끝나면 Close를 호출해야 합니다
+func NewLDAPServer(entries ...LDAPEntry) (*LDAPServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &LDAPServer{listener: listener, entries: entries, conns: make(map[net.Conn]struct{})}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// URL은 서버 주소입니다 (ldap://127.0.0.1:<포트>)
+func (s *LDAPServer) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// PutEntry는 디렉터리에 항목을 추가하거나, 같은 DN의 항목을 바꿉니다
+func (s *LDAPServer) PutEntry(entry LDAPEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.entries {
+		if strings.EqualFold(s.entries[i].DN, entry.DN) {
+			s.entries[i] = entry
+			return
+		}
+	}
+	s.entries = append(s.entries, entry)
+}
+
+// Binds는 지금까지 성공한 바인드의 DN 목록입니다
+func (s *LDAPServer) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.binds...)
+}
+
+// Close는 서버를 멈추고 열린 연결을 모두 닫습니다. 이후 연결은 실패합니다
+func (s *LDAPServer) Close() {
+	s.listener.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *LDAPServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+func (s *LDAPServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	bound := false
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case ldapBindRequest:
+			code := s.bind(op)
+			bound = code == ldapSuccess && ldapString(op.Children[1]) != ""
+			writePacket(conn, ldapResult(messageID, ldapBindResponse, code))
+
+		case ldapSearchRequest:
+			if !bound {
+				writePacket(conn, ldapResult(messageID, ldapSearchResultDone, ldapInsufficientAccessRight))
+				continue
+			}
+			entries, code := s.search(op)
+			for _, entry := range entries {
+				writePacket(conn, entry.encode(messageID))
+			}
+			writePacket(conn, ldapResult(messageID, ldapSearchResultDone, code))
+
+		case ldapUnbindRequest:
+			return
+
+		default:
+			writePacket(conn, ldapResult(messageID, ldapSearchResultDone, ldapUnwillingToPerform))
+		}
+	}
+}
+
+// bind는 단순 바인드를 처리합니다. 빈 비밀번호는 실제 서버처럼 익명 바인드로 성공합니다
+func (s *LDAPServer) bind(op *ber.Packet) int {
+	if len(op.Children) < 3 {
+		return ldapUnwillingToPerform
+	}
+	dn := ldapString(op.Children[1])
+	password := ldapString(op.Children[2])
+	if password == "" {
+		return ldapSuccess
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if strings.EqualFold(entry.DN, dn) && entry.Password != "" && entry.Password == password {
+			s.binds = append(s.binds, entry.DN)
+			return ldapSuccess
+		}
+	}
+	return ldapInvalidCredentials
+}
+
+func (s *LDAPServer) search(op *ber.Packet) ([]LDAPEntry, int) {
+	if len(op.Children) < 8 {
+		return nil, ldapUnwillingToPerform
+	}
+	baseDN := strings.ToLower(ldapString(op.Children[0]))
+	scope, _ := op.Children[1].Value.(int64)
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+
+	var attributes []string
+	for _, attr := range op.Children[7].Children {
+		attributes = append(attributes, ldapString(attr))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []LDAPEntry
+	for _, entry := range s.entries {
+		dn := strings.ToLower(entry.DN)
+		inScope := dn == baseDN
+		if scope != 0 {
+			inScope = inScope || strings.HasSuffix(dn, ","+baseDN)
+		}
+		if !inScope || !entry.matches(filter) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(matched)) >= sizeLimit {
+			return matched, ldapSizeLimitExceeded
+		}
+		matched = append(matched, entry.project(attributes))
+	}
+	return matched, ldapSuccess
+}
+
+// matches는 검색 필터를 평가합니다. 지원하지 않는 필터는 일치하지 않는 것으로 봅니다
+func (e LDAPEntry) matches(filter *ber.Packet) bool {
+	switch filter.Tag {
+	case 0: // and
+		for _, child := range filter.Children {
+			if !e.matches(child) {
+				return false
+			}
+		}
+		return true
+	case 1: // or
+		for _, child := range filter.Children {
+			if e.matches(child) {
+				return true
+			}
+		}
+		return false
+	case 2: // not
+		return len(filter.Children) == 1 && !e.matches(filter.Children[0])
+	case 3: // equalityMatch
+		if len(filter.Children) != 2 {
+			return false
+		}
+		name, value := ldapString(filter.Children[0]), ldapString(filter.Children[1])
+		for _, v := range e.values(name) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case 7: // present
+		return len(e.values(filter.Data.String())) > 0
+	}
+	return false
+}
+
+// values는 속성 값을 반환합니다. 속성 이름은 대소문자를 구별하지 않습니다
+func (e LDAPEntry) values(name string) []string {
+	if strings.EqualFold(name, "objectClass") && len(e.Attributes["objectClass"]) == 0 {
+		return []string{"top"}
+	}
+	for attr, values := range e.Attributes {
+		if strings.EqualFold(attr, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// project는 요청된 속성만 남긴 항목을 반환합니다 (요청이 없으면 전체)
+func (e LDAPEntry) project(attributes []string) LDAPEntry {
+	if len(attributes) == 0 {
+		return LDAPEntry{DN: e.DN, Attributes: e.Attributes}
+	}
+	projected := LDAPEntry{DN: e.DN, Attributes: make(map[string][]string)}
+	for _, name := range attributes {
+		if values := e.values(name); len(values) > 0 {
+			projected.Attributes[name] = values
+		}
+	}
+	return projected
+}
+
+func (e LDAPEntry) encode(messageID int64) *ber.Packet {
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldapSearchResultItem, nil, "Search Result Entry")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "Object Name"))
+
+	attributes := ber.NewSequence("Attributes")
+	for name, values := range e.Attributes {
+		attribute := ber.NewSequence("Attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attribute.AppendChild(set)
+		attributes.AppendChild(attribute)
+	}
+	entry.AppendChild(attributes)
+
+	return ldapMessage(messageID, entry)
+}
+
+func ldapResult(messageID int64, tag ber.Tag, code int) *ber.Packet {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return ldapMessage(messageID, result)
+}
+
+func ldapMessage(messageID int64, op *ber.Packet) *ber.Packet {
+	packet := ber.NewSequence("LDAP Message")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+	packet.AppendChild(op)
+	return packet
+}
+
+func ldapString(p *ber.Packet) string {
+	return p.Data.String()
+}
+
+func writePacket(conn net.Conn, packet *ber.Packet) {
+	conn.Write(packet.Bytes())
+}
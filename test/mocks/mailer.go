@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"mult-working/pkg/mailer"
+)
+
+// Mailer는 보낸 메일을 기록하는 테스트용 mailer.Mailer입니다
+type Mailer struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+}
+
+// Send는 메일을 기록합니다
+func (m *Mailer) Send(_ context.Context, msg mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// Sent는 지금까지 보낸 메일을 반환합니다
+func (m *Mailer) Sent() []mailer.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]mailer.Message(nil), m.sent...)
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"sync"
+
+	"mult-working/internal/events"
+)
+
+// Publisher는 발행된 이벤트를 기록하는 테스트용 events.Publisher입니다
+type Publisher struct {
+	mu        sync.Mutex
+	published []events.Event
+	err       error
+}
+
+// Publish는 이벤트를 기록합니다. FailWith로 에러를 지정했으면 기록하지 않고 에러를 반환합니다
+func (p *Publisher) Publish(event events.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+// FailWith는 이후 발행이 err를 반환하도록 합니다. nil이면 다시 기록합니다
+func (p *Publisher) FailWith(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// Published는 지금까지 기록된 이벤트를 반환합니다
+func (p *Publisher) Published() []events.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]events.Event(nil), p.published...)
+}
+
+// OfType은 지정된 타입의 이벤트만 반환합니다
+func (p *Publisher) OfType(eventType string) []events.Event {
+	var matched []events.Event
+	for _, e := range p.Published() {
+		if e.Type == eventType {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,105 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+)
+
+func TestOnlyOwnerCanDeleteRoom(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	admin := f.member(t, roomID, "admin", models.RoomRoleAdmin)
+
+	assert.ErrorIs(t, f.rooms.DeleteRoom(roomID, admin), errors.ErrPermissionDenied)
+	assert.ErrorIs(t, f.rooms.DeleteRoom(roomID, f.user(t, "outsider")), errors.ErrPermissionDenied)
+
+	_, err := f.rooms.GetRoom(roomID, owner)
+	require.NoError(t, err)
+}
+
+func TestDeleteRoomCascades(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	f.member(t, roomID, "member", models.RoomRoleMember)
+	banned := f.member(t, roomID, "banned", models.RoomRoleMember)
+
+	_, err := f.rooms.CreateInvite(roomID, owner, dto.CreateInviteRequest{})
+	require.NoError(t, err)
+	_, err = f.rooms.BanMember(roomID, owner, banned, dto.BanMemberRequest{})
+	require.NoError(t, err)
+	link, err := f.guests.CreateShareLink(roomID, owner, dto.CreateShareLinkRequest{CanWrite: true, ExpiresInHours: 1})
+	require.NoError(t, err)
+	guest, err := f.guests.JoinAsGuest(dto.GuestJoinRequest{Token: link.Token, DisplayName: "visitor"}, service.ClientInfo{})
+	require.NoError(t, err)
+
+	// 다른 채팅방은 영향 없음
+	otherRoom := f.room(t, owner, models.RoomPublic)
+	_, err = f.rooms.CreateInvite(otherRoom, owner, dto.CreateInviteRequest{})
+	require.NoError(t, err)
+
+	var updates []service.RoomUpdate
+	f.rooms.OnRoomUpdated(func(u service.RoomUpdate) { updates = append(updates, u) })
+
+	require.NoError(t, f.rooms.DeleteRoom(roomID, owner))
+
+	_, err = f.rooms.GetRoom(roomID, owner)
+	assert.ErrorIs(t, err, errors.ErrRoomNotFound)
+
+	count := func(model interface{}, roomID uint) int64 {
+		var n int64
+		require.NoError(t, f.db.Model(model).Where("room_id = ?", roomID).Count(&n).Error)
+		return n
+	}
+	assert.Zero(t, count(&models.RoomUser{}, roomID))
+	assert.Zero(t, count(&models.RoomInvite{}, roomID))
+	assert.Zero(t, count(&models.RoomBan{}, roomID))
+	assert.Equal(t, int64(1), count(&models.RoomInvite{}, otherRoom))
+	assert.Equal(t, int64(1), count(&models.RoomUser{}, otherRoom))
+
+	var links []models.RoomShareLink
+	require.NoError(t, f.db.Where("room_id = ?", roomID).Find(&links).Error)
+	require.Len(t, links, 1)
+	assert.NotNil(t, links[0].RevokedAt)
+	_, err = f.guests.JoinAsGuest(dto.GuestJoinRequest{Token: link.Token, DisplayName: "late"}, service.ClientInfo{})
+	assert.ErrorIs(t, err, errors.ErrShareLinkNotFound)
+
+	// 채팅방 게스트의 토큰은 더 이상 통과하지 않음
+	_, err = f.authService.VerifyToken(guest.Token)
+	assert.ErrorIs(t, err, errors.ErrTokenRevoked)
+
+	require.Len(t, updates, 1)
+	assert.True(t, updates[0].Deleted)
+	assert.Equal(t, []uint{guest.UserID}, updates[0].RevokedGuests)
+}
+
+func TestArchivedRoomMessagesCannotBeDeleted(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	author := f.member(t, roomID, "author", models.RoomRoleMember)
+
+	own, err := f.messages.CreateMessage(dto.CreateMessageRequest{RoomID: roomID, Content: "hello"}, author)
+	require.NoError(t, err)
+	other, err := f.messages.CreateMessage(dto.CreateMessageRequest{RoomID: roomID, Content: "welcome"}, owner)
+	require.NoError(t, err)
+
+	_, err = f.rooms.ArchiveRoom(roomID, owner)
+	require.NoError(t, err)
+
+	// 작성자 본인도, 소유자도 삭제할 수 없음
+	assert.ErrorIs(t, f.messages.DeleteMessage(own.ID, author), errors.ErrRoomArchived)
+	assert.ErrorIs(t, f.messages.DeleteMessage(other.ID, owner), errors.ErrRoomArchived)
+
+	// 보관을 해제하면 다시 삭제 가능
+	_, err = f.rooms.UnarchiveRoom(roomID, owner)
+	require.NoError(t, err)
+	require.NoError(t, f.messages.DeleteMessage(own.ID, author))
+}
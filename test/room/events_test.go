@@ -0,0 +1,128 @@
+package room
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+)
+
+// assertEnvelope는 이벤트 봉투의 공통 필드를 확인하고, 직렬화 결과가 스키마의 필수 필드를 모두 담는지 확인합니다
+func assertEnvelope(t *testing.T, event events.Event, eventType string, roomID uint) {
+	t.Helper()
+	assert.Equal(t, eventType, event.Type)
+	assert.Equal(t, 1, event.Version)
+	assert.Equal(t, events.RoomKey(roomID), event.Key)
+	assert.Len(t, event.ID, 32)
+	assert.Equal(t, config.ServerInstanceID, event.Source)
+	assert.WithinDuration(t, time.Now(), event.OccurredAt, time.Minute)
+	assert.Equal(t, time.UTC, event.OccurredAt.Location())
+
+	raw, err := events.Schema(event.Type, event.Version)
+	require.NoError(t, err, "스키마가 없는 이벤트 타입/버전")
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties struct {
+			Type    struct{ Const string }      `json:"type"`
+			Version struct{ Const int }         `json:"version"`
+			Data    struct{ Required []string } `json:"data"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &schema))
+	assert.Equal(t, event.Type, schema.Properties.Type.Const)
+	assert.Equal(t, event.Version, schema.Properties.Version.Const)
+
+	encoded, err := json.Marshal(event)
+	require.NoError(t, err)
+	var envelope map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(encoded, &envelope))
+	for _, field := range schema.Required {
+		assert.Contains(t, envelope, field)
+	}
+	var data map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(envelope["data"], &data))
+	for _, field := range schema.Properties.Data.Required {
+		assert.Contains(t, data, field)
+	}
+}
+
+func TestCreateMessagePublishesMessageCreated(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+
+	msg, err := f.messages.CreateMessage(dto.CreateMessageRequest{RoomID: roomID, Content: "hello"}, owner)
+	require.NoError(t, err)
+
+	published := f.events.OfType(events.TypeMessageCreated)
+	require.Len(t, published, 1)
+	assertEnvelope(t, published[0], events.TypeMessageCreated, roomID)
+	assert.Equal(t, events.MessageCreated{MessageID: msg.ID, RoomID: roomID, UserID: owner, Content: "hello"}, published[0].Data)
+}
+
+func TestJoinAndLeavePublishMemberEvents(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	alice := f.user(t, "alice")
+
+	require.NoError(t, f.rooms.JoinRoom(roomID, alice, ""))
+	joined := f.events.OfType(events.TypeRoomMemberJoined)
+	require.Len(t, joined, 1)
+	assertEnvelope(t, joined[0], events.TypeRoomMemberJoined, roomID)
+	assert.Equal(t, events.RoomMemberJoined{RoomID: roomID, UserID: alice}, joined[0].Data)
+
+	require.NoError(t, f.rooms.LeaveRoom(roomID, alice))
+	left := f.events.OfType(events.TypeRoomMemberLeft)
+	require.Len(t, left, 1)
+	assertEnvelope(t, left[0], events.TypeRoomMemberLeft, roomID)
+	assert.Equal(t, events.RoomMemberLeft{RoomID: roomID, UserID: alice}, left[0].Data)
+
+	// 같은 방의 이벤트는 같은 키로 발행되어 순서가 유지됨
+	assert.Equal(t, joined[0].Key, left[0].Key)
+	assert.NotEqual(t, joined[0].ID, left[0].ID)
+}
+
+func TestGuestJoinPublishesGuestMemberJoined(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+
+	guest := f.guest(t, roomID, owner, false)
+	joined := f.events.OfType(events.TypeRoomMemberJoined)
+	require.Len(t, joined, 1)
+	assertEnvelope(t, joined[0], events.TypeRoomMemberJoined, roomID)
+	data, ok := joined[0].Data.(events.RoomMemberJoined)
+	require.True(t, ok)
+	assert.Equal(t, events.RoomMemberJoined{RoomID: roomID, UserID: guest.UserID, Guest: true}, data)
+
+	// guest는 선택 필드라 일반 멤버 이벤트에서는 직렬화하지 않음
+	encoded, err := json.Marshal(events.RoomMemberJoined{RoomID: roomID, UserID: owner})
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "guest")
+}
+
+func TestFailedPublishDoesNotFailRequest(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+
+	f.events.FailWith(stderrors.New("broker down"))
+	_, err := f.messages.CreateMessage(dto.CreateMessageRequest{RoomID: roomID, Content: "still stored"}, owner)
+	require.NoError(t, err)
+	assert.Empty(t, f.events.OfType(events.TypeMessageCreated))
+
+	var count int64
+	require.NoError(t, f.db.Model(&models.Message{}).Where("room_id = ?", roomID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// nil 발행자는 무시됨
+	events.PublishOrLog(nil, events.New(events.TypeMessageCreated, 1, events.RoomKey(roomID), nil))
+}
@@ -0,0 +1,92 @@
+package room
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"mult-working/internal/config"
+	"mult-working/internal/dto"
+	"mult-working/internal/events"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+	"mult-working/test/mocks"
+)
+
+type roomFixture struct {
+	db          *gorm.DB
+	authService *service.AuthService
+	rooms       *service.RoomService
+	guests      *service.GuestService
+	messages    *service.MessageService
+	events      *mocks.Publisher
+}
+
+// newRoomFixture는 인메모리 DB 위에 채팅방, 게스트, 메시지 서비스를 생성합니다. 발행된 이벤트는 events에 기록됩니다
+func newRoomFixture(t *testing.T) *roomFixture {
+	t.Helper()
+
+	db, err := mocks.NewTestDatabase()
+	require.NoError(t, err)
+
+	key, err := service.GenerateKey(service.TokenModeLocal)
+	require.NoError(t, err)
+	authService, err := service.NewAuthService(db, config.AuthConfig{
+		ActiveKeyID: "test",
+		Keys:        []config.AuthKeyConfig{{ID: "test", Secret: key.Secret}},
+	}, events.NoopPublisher{})
+	require.NoError(t, err)
+
+	publisher := &mocks.Publisher{}
+	return &roomFixture{
+		db:          db,
+		authService: authService,
+		rooms:       service.NewRoomService(db, publisher, service.NewPresenceStore(db)),
+		guests:      service.NewGuestService(db, authService, publisher),
+		messages:    service.NewMessageService(db, publisher),
+		events:      publisher,
+	}
+}
+
+// user는 테스트용 사용자를 만들고 ID를 반환합니다
+func (f *roomFixture) user(t *testing.T, username string) uint {
+	t.Helper()
+	user := models.User{Username: username, Email: username + "@example.com"}
+	require.NoError(t, f.db.Create(&user).Error)
+	return user.ID
+}
+
+// room은 ownerID가 소유한 채팅방을 만들고 ID를 반환합니다
+func (f *roomFixture) room(t *testing.T, ownerID uint, visibility string) uint {
+	t.Helper()
+	room, err := f.rooms.CreateRoom(dto.CreateRoomRequest{Name: "general", Visibility: visibility}, ownerID)
+	require.NoError(t, err)
+	return room.ID
+}
+
+// guest는 공유 링크로 게스트를 입장시킵니다
+func (f *roomFixture) guest(t *testing.T, roomID, ownerID uint, canWrite bool) *dto.GuestJoinResponse {
+	t.Helper()
+	link, err := f.guests.CreateShareLink(roomID, ownerID, dto.CreateShareLinkRequest{CanWrite: canWrite, ExpiresInHours: 1})
+	require.NoError(t, err)
+	guest, err := f.guests.JoinAsGuest(dto.GuestJoinRequest{Token: link.Token, DisplayName: "visitor"}, service.ClientInfo{})
+	require.NoError(t, err)
+	return guest
+}
+
+// roleOf는 채팅방 멤버의 현재 역할을 반환합니다
+func (f *roomFixture) roleOf(t *testing.T, roomID, userID uint) string {
+	t.Helper()
+	var member models.RoomUser
+	require.NoError(t, f.db.Where("room_id = ? AND user_id = ?", roomID, userID).First(&member).Error)
+	return member.Role
+}
+
+// member는 사용자를 만들어 주어진 역할로 채팅방에 넣고 ID를 반환합니다
+func (f *roomFixture) member(t *testing.T, roomID uint, username, role string) uint {
+	t.Helper()
+	userID := f.user(t, username)
+	require.NoError(t, f.db.Create(&models.RoomUser{RoomID: roomID, UserID: userID, JoinedAt: time.Now(), Role: role}).Error)
+	return userID
+}
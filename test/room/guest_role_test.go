@@ -0,0 +1,38 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+func TestReadOnlyGuestCannotBePromoted(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	guest := f.guest(t, roomID, owner, false)
+
+	_, err := f.rooms.SetMemberRole(roomID, owner, guest.UserID, models.RoomRoleMember)
+	assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	assert.Equal(t, models.RoomRoleReadOnly, f.roleOf(t, roomID, guest.UserID))
+}
+
+func TestWritableGuestRoleIsCappedAtMember(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	guest := f.guest(t, roomID, owner, true)
+
+	_, err := f.rooms.SetMemberRole(roomID, owner, guest.UserID, models.RoomRoleModerator)
+	assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+
+	// 부여된 역할 안에서는 강등했다가 되돌릴 수 있음
+	_, err = f.rooms.SetMemberRole(roomID, owner, guest.UserID, models.RoomRoleReadOnly)
+	require.NoError(t, err)
+	_, err = f.rooms.SetMemberRole(roomID, owner, guest.UserID, models.RoomRoleMember)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoomRoleMember, f.roleOf(t, roomID, guest.UserID))
+}
@@ -0,0 +1,73 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+func memberNames(t *testing.T, f *roomFixture, roomID, userID uint, prefix string, limit int) []string {
+	t.Helper()
+	var names []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 20, "pagination does not terminate")
+		page, err := f.rooms.ListMembers(roomID, userID, prefix, cursor, limit)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page.Members), limit)
+		for _, m := range page.Members {
+			names = append(names, m.Username)
+		}
+		if page.NextCursor == "" {
+			return names
+		}
+		cursor = page.NextCursor
+	}
+}
+
+func TestListMembersPaginatesInUsernameOrder(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "mallory")
+	roomID := f.room(t, owner, models.RoomPublic)
+	for _, name := range []string{"frank", "alice", "erin", "carol", "dave", "bob"} {
+		f.member(t, roomID, name, models.RoomRoleMember)
+	}
+
+	first, err := f.rooms.ListMembers(roomID, owner, "", "", 3)
+	require.NoError(t, err)
+	require.Len(t, first.Members, 3)
+	assert.NotEmpty(t, first.NextCursor)
+
+	// 페이지를 이어 붙이면 중복이나 누락 없이 전체 목록
+	assert.Equal(t, []string{"alice", "bob", "carol", "dave", "erin", "frank", "mallory"}, memberNames(t, f, roomID, owner, "", 3))
+	// 마지막 페이지가 꽉 차도 빈 페이지를 하나 더 요구하지 않음
+	assert.Len(t, memberNames(t, f, roomID, owner, "", 7), 7)
+}
+
+func TestListMembersPrefixEscapesWildcards(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	for _, name := range []string{"a_b", "axb", "a%c", "abc", "A_Z"} {
+		f.member(t, roomID, name, models.RoomRoleMember)
+	}
+
+	assert.Equal(t, []string{"A_Z", "a_b"}, memberNames(t, f, roomID, owner, "a_", 1))
+	assert.Equal(t, []string{"a%c"}, memberNames(t, f, roomID, owner, "a%", 10))
+	assert.Equal(t, []string{"abc"}, memberNames(t, f, roomID, owner, "AB", 10))
+}
+
+func TestListMembersRejectsInvalidCursorAndOutsiders(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPrivate)
+
+	_, err := f.rooms.ListMembers(roomID, owner, "", "not base64!", 10)
+	assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+
+	_, err = f.rooms.ListMembers(roomID, f.user(t, "outsider"), "", "", 10)
+	assert.ErrorIs(t, err, errors.ErrRoomNotFound)
+}
@@ -0,0 +1,96 @@
+package room
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+	"mult-working/internal/service"
+)
+
+func TestBanBlocksRejoinAndSubscribe(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	target := f.member(t, roomID, "target", models.RoomRoleMember)
+
+	var removals []service.RoomRemoval
+	f.rooms.OnMemberRemoved(func(r service.RoomRemoval) { removals = append(removals, r) })
+
+	_, err := f.rooms.BanMember(roomID, owner, target, dto.BanMemberRequest{Reason: "spam"})
+	require.NoError(t, err)
+	require.Len(t, removals, 1)
+	assert.True(t, removals[0].Banned)
+
+	assert.ErrorIs(t, f.rooms.JoinRoom(roomID, target, ""), errors.ErrBanned)
+	assert.ErrorIs(t, f.messages.CanSubscribe(roomID, target), errors.ErrBanned)
+
+	// 초대 코드가 있어도 차단이 우선
+	invite, err := f.rooms.CreateInvite(roomID, owner, dto.CreateInviteRequest{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, f.rooms.JoinRoom(roomID, target, invite.Code), errors.ErrBanned)
+
+	require.NoError(t, f.rooms.UnbanMember(roomID, owner, target))
+	require.NoError(t, f.messages.CanSubscribe(roomID, target))
+	require.NoError(t, f.rooms.JoinRoom(roomID, target, ""))
+}
+
+func TestBanBeforeJoinBlocksPublicRoomSubscribe(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	outsider := f.user(t, "outsider")
+
+	// 공개 채팅방은 멤버가 아니어도 구독할 수 있으므로 미리 차단할 수 있어야 함
+	require.NoError(t, f.messages.CanSubscribe(roomID, outsider))
+	_, err := f.rooms.BanMember(roomID, owner, outsider, dto.BanMemberRequest{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, f.messages.CanSubscribe(roomID, outsider), errors.ErrBanned)
+}
+
+func TestExpiredBanNoLongerBlocks(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	target := f.member(t, roomID, "target", models.RoomRoleMember)
+
+	_, err := f.rooms.BanMember(roomID, owner, target, dto.BanMemberRequest{DurationHours: 1})
+	require.NoError(t, err)
+	assert.ErrorIs(t, f.rooms.JoinRoom(roomID, target, ""), errors.ErrBanned)
+
+	require.NoError(t, f.db.Model(&models.RoomBan{}).Where("user_id = ?", target).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	require.NoError(t, f.rooms.JoinRoom(roomID, target, ""))
+
+	bans, err := f.rooms.ListBans(roomID, owner)
+	require.NoError(t, err)
+	assert.Empty(t, bans)
+}
+
+func TestKickedMemberCanRejoin(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	target := f.member(t, roomID, "target", models.RoomRoleMember)
+
+	require.NoError(t, f.rooms.KickMember(roomID, owner, target, "cool down"))
+	assert.ErrorIs(t, f.rooms.LeaveRoom(roomID, target), errors.ErrNotJoined)
+	require.NoError(t, f.rooms.JoinRoom(roomID, target, ""))
+}
+
+func TestModeratorCannotBanHigherRole(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	moderator := f.member(t, roomID, "moderator", models.RoomRoleModerator)
+	admin := f.member(t, roomID, "admin", models.RoomRoleAdmin)
+
+	_, err := f.rooms.BanMember(roomID, moderator, admin, dto.BanMemberRequest{})
+	assert.ErrorIs(t, err, errors.ErrPermissionDenied)
+	assert.ErrorIs(t, f.rooms.KickMember(roomID, moderator, owner, ""), errors.ErrPermissionDenied)
+	assert.Equal(t, models.RoomRoleAdmin, f.roleOf(t, roomID, admin))
+}
@@ -0,0 +1,186 @@
+package room
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+	"mult-working/pkg/database"
+	"mult-working/test/mocks"
+)
+
+var roomRoles = []string{models.RoomRoleOwner, models.RoomRoleAdmin, models.RoomRoleModerator, models.RoomRoleMember, models.RoomRoleReadOnly}
+
+func TestRoomRoleRanks(t *testing.T) {
+	// roomRoles는 높은 역할부터 나열되어 있음
+	for i, role := range roomRoles {
+		for j, required := range roomRoles {
+			assert.Equal(t, i <= j, models.RoomRoleAtLeast(role, required), "%s at least %s", role, required)
+		}
+	}
+	assert.False(t, models.RoomRoleAtLeast("superuser", models.RoomRoleReadOnly))
+}
+
+func TestSetMemberRoleMatrix(t *testing.T) {
+	rank := func(role string) int {
+		for i, r := range roomRoles {
+			if r == role {
+				return len(roomRoles) - i
+			}
+		}
+		return 0
+	}
+
+	f := newRoomFixture(t)
+	for _, actorRole := range roomRoles {
+		for _, targetRole := range roomRoles[1:] {
+			for _, newRole := range roomRoles[1:] {
+				name := fmt.Sprintf("%s sets %s to %s", actorRole, targetRole, newRole)
+				t.Run(name, func(t *testing.T) {
+					owner := f.user(t, "owner-"+name)
+					roomID := f.room(t, owner, models.RoomPublic)
+					actor := owner
+					if actorRole != models.RoomRoleOwner {
+						actor = f.member(t, roomID, "actor-"+name, actorRole)
+					}
+					target := f.member(t, roomID, "target-"+name, targetRole)
+
+					// 관리자 이상이 자기보다 낮은 멤버를 자기보다 낮은 역할로만 바꿀 수 있음
+					allowed := rank(actorRole) >= rank(models.RoomRoleAdmin) &&
+						rank(actorRole) > rank(targetRole) && rank(actorRole) > rank(newRole)
+
+					_, err := f.rooms.SetMemberRole(roomID, actor, target, newRole)
+					if allowed {
+						require.NoError(t, err)
+						assert.Equal(t, newRole, f.roleOf(t, roomID, target))
+					} else {
+						assert.ErrorIs(t, err, errors.ErrPermissionDenied)
+						assert.Equal(t, targetRole, f.roleOf(t, roomID, target))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSetMemberRoleCannotGrantOwnership(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+	admin := f.member(t, roomID, "admin", models.RoomRoleAdmin)
+
+	_, err := f.rooms.SetMemberRole(roomID, owner, admin, models.RoomRoleOwner)
+	assert.ErrorIs(t, err, errors.ErrInvalidRequest)
+	_, err = f.rooms.SetMemberRole(roomID, owner, owner, models.RoomRoleAdmin)
+	assert.ErrorIs(t, err, errors.ErrPermissionDenied)
+}
+
+func TestRoomActionsRequireMinimumRole(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPublic)
+
+	topic := "release planning"
+	actions := []struct {
+		name     string
+		required string
+		run      func(userID uint) error
+	}{
+		{"create invite", models.RoomRoleModerator, func(userID uint) error {
+			_, err := f.rooms.CreateInvite(roomID, userID, dto.CreateInviteRequest{})
+			return err
+		}},
+		{"update settings", models.RoomRoleAdmin, func(userID uint) error {
+			_, err := f.rooms.UpdateRoom(roomID, userID, dto.UpdateRoomRequest{Topic: &topic})
+			return err
+		}},
+		{"create share link", models.RoomRoleAdmin, func(userID uint) error {
+			_, err := f.guests.CreateShareLink(roomID, userID, dto.CreateShareLinkRequest{ExpiresInHours: 1})
+			return err
+		}},
+	}
+
+	for _, role := range roomRoles[1:] {
+		userID := f.member(t, roomID, role, role)
+		for _, action := range actions {
+			err := action.run(userID)
+			if models.RoomRoleAtLeast(role, action.required) {
+				assert.NoError(t, err, "%s: %s", role, action.name)
+			} else {
+				assert.ErrorIs(t, err, errors.ErrPermissionDenied, "%s: %s", role, action.name)
+			}
+		}
+	}
+
+	// 멤버가 아니면 역할이 없는 것과 같음
+	assert.ErrorIs(t, actions[0].run(f.user(t, "outsider")), errors.ErrPermissionDenied)
+}
+
+func TestMigrateLegacyRoomAdminFlags(t *testing.T) {
+	db, err := mocks.NewTestDatabase()
+	require.NoError(t, err)
+
+	// 역할 도입 전 스키마: is_admin, read_only 플래그
+	require.NoError(t, db.Exec("ALTER TABLE room_users ADD COLUMN `is_admin` numeric DEFAULT false").Error)
+	require.NoError(t, db.Exec("ALTER TABLE room_users ADD COLUMN `read_only` numeric DEFAULT false").Error)
+
+	users := map[string]uint{}
+	for _, name := range []string{"creator", "admin", "member", "reader", "other-creator"} {
+		user := models.User{Username: name, Email: name + "@example.com"}
+		require.NoError(t, db.Create(&user).Error)
+		users[name] = user.ID
+	}
+	room := models.Room{Name: "legacy", Visibility: models.RoomPublic, CreatedBy: users["creator"]}
+	require.NoError(t, db.Create(&room).Error)
+	// 생성자가 관리자 플래그를 잃은 채팅방
+	demoted := models.Room{Name: "demoted", Visibility: models.RoomPublic, CreatedBy: users["other-creator"]}
+	require.NoError(t, db.Create(&demoted).Error)
+
+	legacy := []struct {
+		roomID   uint
+		user     string
+		isAdmin  bool
+		readOnly bool
+	}{
+		{room.ID, "creator", true, false},
+		{room.ID, "admin", true, false},
+		{room.ID, "member", false, false},
+		{room.ID, "reader", false, true},
+		{demoted.ID, "other-creator", false, false},
+	}
+	for _, m := range legacy {
+		require.NoError(t, db.Exec("INSERT INTO room_users (room_id, user_id, joined_at, role, is_admin, read_only) VALUES (?, ?, ?, ?, ?, ?)",
+			m.roomID, users[m.user], time.Now(), models.RoomRoleMember, m.isAdmin, m.readOnly).Error)
+	}
+
+	require.NoError(t, database.DBAutoMigrate(db))
+
+	roles := map[string]string{}
+	var members []models.RoomUser
+	require.NoError(t, db.Find(&members).Error)
+	for _, m := range members {
+		for name, id := range users {
+			if id == m.UserID {
+				roles[name] = m.Role
+			}
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"creator":       models.RoomRoleOwner,
+		"admin":         models.RoomRoleAdmin,
+		"member":        models.RoomRoleMember,
+		"reader":        models.RoomRoleReadOnly,
+		"other-creator": models.RoomRoleMember,
+	}, roles)
+
+	assert.False(t, db.Migrator().HasColumn(&models.RoomUser{}, "is_admin"))
+	assert.False(t, db.Migrator().HasColumn(&models.RoomUser{}, "read_only"))
+
+	// 두 번 실행해도 그대로
+	require.NoError(t, database.DBAutoMigrate(db))
+}
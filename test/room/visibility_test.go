@@ -0,0 +1,88 @@
+package room
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"mult-working/internal/dto"
+	"mult-working/internal/errors"
+	"mult-working/internal/models"
+)
+
+func TestPrivateRoomIsNotFoundForNonMembers(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	outsider := f.user(t, "outsider")
+	roomID := f.room(t, owner, models.RoomPrivate)
+
+	_, err := f.rooms.GetRoom(roomID, outsider)
+	assert.ErrorIs(t, err, errors.ErrRoomNotFound)
+	assert.Equal(t, http.StatusNotFound, errors.MapError(err).StatusCode)
+
+	// 초대 없이 참여하려 해도 없는 채팅방과 같은 응답
+	err = f.rooms.JoinRoom(roomID, outsider, "")
+	assert.ErrorIs(t, err, errors.ErrRoomNotFound)
+	assert.Equal(t, http.StatusNotFound, errors.MapError(err).StatusCode)
+
+	rooms, err := f.rooms.GetRooms()
+	require.NoError(t, err)
+	assert.Empty(t, rooms)
+
+	room, err := f.rooms.GetRoom(roomID, owner)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoomPrivate, room.Visibility)
+}
+
+func TestInviteOnlyRoomRequiresInvite(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	outsider := f.user(t, "outsider")
+	roomID := f.room(t, owner, models.RoomInviteOnly)
+
+	// 목록과 정보는 보이지만 참여에는 초대가 필요
+	_, err := f.rooms.GetRoom(roomID, outsider)
+	require.NoError(t, err)
+	assert.ErrorIs(t, f.rooms.JoinRoom(roomID, outsider, ""), errors.ErrInviteRequired)
+
+	invite, err := f.rooms.CreateInvite(roomID, owner, dto.CreateInviteRequest{})
+	require.NoError(t, err)
+	require.NoError(t, f.rooms.JoinRoom(0, outsider, invite.Code))
+	assert.Equal(t, models.RoomRoleMember, f.roleOf(t, roomID, outsider))
+}
+
+func TestInviteStopsAtMaxUses(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	roomID := f.room(t, owner, models.RoomPrivate)
+
+	invite, err := f.rooms.CreateInvite(roomID, owner, dto.CreateInviteRequest{MaxUses: 2})
+	require.NoError(t, err)
+
+	require.NoError(t, f.rooms.JoinRoom(roomID, f.user(t, "first"), invite.Code))
+	second := f.user(t, "second")
+	require.NoError(t, f.rooms.JoinRoom(roomID, second, invite.Code))
+
+	// 이미 참여한 사용자가 다시 써도 사용 횟수는 늘지 않음
+	assert.ErrorIs(t, f.rooms.JoinRoom(roomID, second, invite.Code), errors.ErrInviteNotFound)
+	assert.ErrorIs(t, f.rooms.JoinRoom(roomID, f.user(t, "third"), invite.Code), errors.ErrInviteNotFound)
+
+	invites, err := f.rooms.ListInvites(roomID, owner)
+	require.NoError(t, err)
+	require.Len(t, invites, 1)
+	assert.Equal(t, 2, invites[0].Uses)
+}
+
+func TestDirectInviteOnlyWorksForInvitee(t *testing.T) {
+	f := newRoomFixture(t)
+	owner := f.user(t, "owner")
+	invitee := f.user(t, "invitee")
+	roomID := f.room(t, owner, models.RoomPrivate)
+
+	invite, err := f.rooms.CreateInvite(roomID, owner, dto.CreateInviteRequest{InviteeID: &invitee})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, f.rooms.JoinRoom(roomID, f.user(t, "someone"), invite.Code), errors.ErrInviteNotFound)
+	require.NoError(t, f.rooms.JoinRoom(roomID, invitee, invite.Code))
+}